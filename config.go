@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// configFileName is the configuration file toe reads from the module root when it is
+// run without arguments.
+const configFileName = ".toe.json"
+
+// config lists every stub to generate for a module.
+type config struct {
+	Stubs []stubConfig `json:"stubs"`
+}
+
+// stubConfig selects interfaces from a package and describes where their stubs are
+// written. Relative paths are resolved against the directory holding the config file.
+type stubConfig struct {
+	Package     string            `json:"package"`      // Directory or package pattern; "" if Interfaces are importpath.Interface selectors
+	Interfaces  []interfaceConfig `json:"interfaces"`   // Interfaces to stub, by name or as objects with overrides
+	All         bool              `json:"all"`          // Stub every exported interface in Package
+	StubDir     string            `json:"stub_dir"`     // Directory stub files are written to; defaults to "stubs"
+	Output      string            `json:"output"`       // Single output file, as with -o
+	Combine     bool              `json:"combine"`      // Write the stubs of each package to a single file
+	PackageName string            `json:"package_name"` // Package name of the stubs; defaults to the directory name
+
+	// Naming templates, as described by StubNaming
+	StubName        string `json:"stub_name"`
+	ConstructorName string `json:"constructor_name"`
+	FuncName        string `json:"func_name"`
+	CallsName       string `json:"calls_name"`
+	ReturnsName     string `json:"returns_name"`
+	CallTypeName    string `json:"call_type_name"`
+	ReturnsTypeName string `json:"returns_type_name"`
+	ResultNames     string `json:"result_names"` // Result field policy: "type", "index" or "named"
+}
+
+// interfaceConfig names an interface to stub, optionally overriding where its stub is
+// written and what it is called.
+type interfaceConfig struct {
+	Name     string `json:"name"`
+	Output   string `json:"output"`
+	StubName string `json:"stub_name"`
+}
+
+// UnmarshalJSON accepts either a bare interface name or an object with overrides.
+func (c *interfaceConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*c = interfaceConfig{}
+		return json.Unmarshal(data, &c.Name)
+	}
+	type plain interfaceConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode((*plain)(c))
+}
+
+// findConfig returns the path of the config file at the root of the module containing
+// dir, or in dir itself if it is not inside a module.
+func findConfig(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	root := absDir
+	for {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			root = absDir
+			break
+		}
+		root = parent
+	}
+
+	path := filepath.Join(root, configFileName)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no %s found in %s", configFileName, root)
+	}
+	return path, nil
+}
+
+// loadConfig reads and validates the config file at path.
+func loadConfig(path string) (*config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg config
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", path, err)
+	}
+
+	if len(cfg.Stubs) == 0 {
+		return nil, fmt.Errorf("%s lists no stubs", path)
+	}
+	for i, stub := range cfg.Stubs {
+		switch {
+		case stub.Package == "" && stub.All:
+			return nil, fmt.Errorf("%s: stubs[%d] sets all without a package", path, i)
+		case !stub.All && len(stub.Interfaces) == 0:
+			return nil, fmt.Errorf("%s: stubs[%d] lists no interfaces", path, i)
+		}
+		for j, iface := range stub.Interfaces {
+			if iface.Name == "" {
+				return nil, fmt.Errorf("%s: stubs[%d].interfaces[%d] has no name", path, i, j)
+			}
+			if _, _, ok := parseInterfaceSelector(iface.Name); stub.Package == "" && !ok {
+				return nil, fmt.Errorf("%s: stubs[%d] has no package, so %s must be of the form importpath.Interface",
+					path, i, iface.Name)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// planConfig loads the packages listed in cfg and plans the stub files for all of them.
+// Relative paths in cfg are resolved against baseDir.
+func planConfig(cfg *config, baseDir string) ([]stubFile, error) {
+	var planner stubPlanner
+	for _, stub := range cfg.Stubs {
+		layout := stubLayout{
+			StubDir:     stub.StubDir,
+			OutputFile:  resolvePath(baseDir, stub.Output),
+			Combine:     stub.Combine,
+			PackageName: stub.PackageName,
+			Naming: StubNaming{
+				Stub:        stub.StubName,
+				Constructor: stub.ConstructorName,
+				Func:        stub.FuncName,
+				Calls:       stub.CallsName,
+				Returns:     stub.ReturnsName,
+				CallType:    stub.CallTypeName,
+				ReturnsType: stub.ReturnsTypeName,
+				Results:     stub.ResultNames,
+			},
+		}
+		if layout.StubDir == "" {
+			layout.StubDir = "stubs"
+		}
+
+		var selections []packageSelection
+		var err error
+		if stub.Package == "" {
+			layout.StubDir = resolvePath(baseDir, layout.StubDir)
+			selections, err = loadSelectors(interfaceNames(stub.Interfaces))
+		} else {
+			// As on the command line, a directory is a single package with stubs placed
+			// relative to the base directory, while a pattern places stubs relative to
+			// each matching package.
+			var pkgs []*packages.Package
+			input := resolvePath(baseDir, stub.Package)
+			if info, statErr := os.Stat(input); statErr == nil && info.IsDir() {
+				layout.StubDir = resolvePath(baseDir, layout.StubDir)
+				pkgs, err = LoadPackages(input, ".")
+			} else {
+				layout.PerPackage = true
+				pkgs, err = LoadPackages(baseDir, stub.Package)
+			}
+			if err == nil {
+				// With all set, any listed interfaces only carry overrides
+				names := interfaceNames(stub.Interfaces)
+				if stub.All {
+					names = nil
+				}
+				selections, err = selectByName(pkgs, names, stub.All)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Interfaces with overrides are planned on their own with the adjusted layout
+		var defaults []packageSelection
+		for _, selection := range selections {
+			defaultSelection := packageSelection{Pkg: selection.Pkg}
+			for _, name := range selection.Names {
+				override, ok := findOverride(stub.Interfaces, selection.Pkg.PkgPath, name)
+				if !ok {
+					defaultSelection.Names = append(defaultSelection.Names, name)
+					continue
+				}
+				overrideLayout := layout
+				if override.Output != "" {
+					overrideLayout.OutputFile = resolvePath(baseDir, override.Output)
+				}
+				if override.StubName != "" {
+					overrideLayout.Naming.Stub = override.StubName
+				}
+				single := []packageSelection{{Pkg: selection.Pkg, Names: []string{name}}}
+				if err := planner.add(single, overrideLayout); err != nil {
+					return nil, err
+				}
+			}
+			if len(defaultSelection.Names) > 0 {
+				defaults = append(defaults, defaultSelection)
+			}
+		}
+		if err := planner.add(defaults, layout); err != nil {
+			return nil, err
+		}
+	}
+	return planner.stubFiles(), nil
+}
+
+// findOverride returns the entry in interfaces overriding the layout of the named
+// interface from the package with the given import path.
+func findOverride(interfaces []interfaceConfig, pkgPath, name string) (interfaceConfig, bool) {
+	for _, iface := range interfaces {
+		if iface.Output == "" && iface.StubName == "" {
+			continue
+		}
+		if iface.Name == name || iface.Name == pkgPath+"."+name {
+			return iface, true
+		}
+	}
+	return interfaceConfig{}, false
+}
+
+// interfaceNames returns the names of the configured interfaces.
+func interfaceNames(interfaces []interfaceConfig) []string {
+	names := make([]string, len(interfaces))
+	for i, iface := range interfaces {
+		names[i] = iface.Name
+	}
+	return names
+}
+
+// resolvePath resolves a path from the config file against baseDir, keeping it relative
+// to the working directory where possible.
+func resolvePath(baseDir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return relativeToWorkingDir(filepath.Join(baseDir, path))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"examples/calculator/stubs"
+
+	"github.com/phildrip/toe/options"
+)
+
+// BenchmarkConcurrentCalls measures the throughput of a locking stub called from a growing
+// number of goroutines, which share the b.N calls between them. Run it with:
+//
+//	go test -bench ConcurrentCalls ./calculator
+func BenchmarkConcurrentCalls(b *testing.B) {
+	for _, goroutines := range []int{1, 2, 4, 8, 16, 32} {
+		b.Run(fmt.Sprintf("goroutines=%d", goroutines), func(b *testing.B) {
+			stub := stubs.NewStubCalculator(options.StubOptions{WithLocking: true})
+			stub.SubtractFunc = func(a, b int) (int, error) {
+				return a - b, nil
+			}
+
+			var wg sync.WaitGroup
+			b.ResetTimer()
+			for g := 0; g < goroutines; g++ {
+				calls := b.N / goroutines
+				if g < b.N%goroutines {
+					calls++
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < calls; i++ {
+						stub.Subtract(i, 1)
+					}
+				}()
+			}
+			wg.Wait()
+			b.StopTimer()
+
+			if got := stub.SubtractCallCount(); got != b.N {
+				b.Fatalf("SubtractCallCount() = %d, want %d", got, b.N)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func main() {
 
 	// Set a single return value for Subtract.
 	// Note: SubtractReturns is now a single struct, not a slice.
-	stub.SubtractReturns = stubs.StubCalculatorSubtractReturns{R0: 100, R1: nil}
+	stub.SubtractReturns = stubs.StubCalculatorSubtractReturns{Int0: 100, Error1: nil}
 	result, err := stub.Subtract(20, 10)
 	if err != nil {
 		log.Fatalf("Error from Subtract: %v", err)
@@ -27,11 +27,24 @@ func main() {
 	fmt.Printf("Subtract(20, 10) returned: %d, %v\n", result, err)
 
 	// If you want to demonstrate an error return, set it again
-	stub.SubtractReturns = stubs.StubCalculatorSubtractReturns{R0: 0,
-		R1: fmt.Errorf("simulated error")}
+	stub.SubtractReturns = stubs.StubCalculatorSubtractReturns{Int0: 0,
+		Error1: fmt.Errorf("simulated error")}
 	result, err = stub.Subtract(5, 3)
 	fmt.Printf("Subtract(5, 3) returned: %d, %v\n", result, err)
 
+	// --- Using sequenced return values ---
+	fmt.Println("\n--- Testing Subtract with a sequence of return values ---")
+
+	// The next calls return each value in turn, taking precedence over SubtractReturns
+	stub.SubtractReturnsSequence(
+		stubs.StubCalculatorSubtractReturns{Int0: 0, Error1: fmt.Errorf("timeout")},
+		stubs.StubCalculatorSubtractReturns{Int0: 2, Error1: nil},
+	)
+	for i := 0; i < 2; i++ {
+		result, err = stub.Subtract(5, 3)
+		fmt.Printf("Subtract(5, 3) returned: %d, %v\n", result, err)
+	}
+
 	// --- Using a lambda function ---
 	fmt.Println("\n--- Testing Add with a lambda function ---")
 	stub.AddFunc = func(a, b int) int {
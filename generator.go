@@ -7,45 +7,49 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"maps"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/phildrip/toe/options"
 )
 
 // typeToExpr converts a types.Type to an ast.Expr, handling package imports.
-func typeToExpr(t types.Type, currentPackageName string, imports map[string]string) ast.Expr {
+func typeToExpr(t types.Type, currentPackagePath string, imports map[string]string) ast.Expr {
 	switch typ := t.(type) {
 	case *types.Basic:
 		return ast.NewIdent(typ.Name())
 	case *types.Named:
 		// If the named type belongs to an external package, use a selector expression
-		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != currentPackageName {
+		var nameExpr ast.Expr = ast.NewIdent(typ.Obj().Name())
+		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != currentPackagePath {
 			// Check if we collected an alias for this package
 			pkgName, ok := imports[typ.Obj().Pkg().Path()]
 			if !ok {
 				pkgName = typ.Obj().Pkg().Name() // Fallback to actual package name
 			}
-			return &ast.SelectorExpr{
+			nameExpr = &ast.SelectorExpr{
 				X:   ast.NewIdent(pkgName),
 				Sel: ast.NewIdent(typ.Obj().Name()),
 			}
 		}
-		// Otherwise, it's a type in the current package or a predeclared type
-		return ast.NewIdent(typ.Obj().Name())
+		// Instantiated generic types carry their type arguments, e.g. Option[string]
+		return instantiateExpr(nameExpr, typ.TypeArgs(), currentPackagePath, imports)
 	case *types.Pointer:
-		return &ast.StarExpr{X: typeToExpr(typ.Elem(), currentPackageName, imports)}
+		return &ast.StarExpr{X: typeToExpr(typ.Elem(), currentPackagePath, imports)}
 	case *types.Slice:
-		return &ast.ArrayType{Elt: typeToExpr(typ.Elem(), currentPackageName, imports)}
+		return &ast.ArrayType{Elt: typeToExpr(typ.Elem(), currentPackagePath, imports)}
 	case *types.Array:
 		return &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT,
 			Value: fmt.Sprintf("%d", typ.Len())},
-			Elt: typeToExpr(typ.Elem(), currentPackageName, imports)}
+			Elt: typeToExpr(typ.Elem(), currentPackagePath, imports)}
 	case *types.Map:
-		return &ast.MapType{Key: typeToExpr(typ.Key(), currentPackageName, imports),
-			Value: typeToExpr(typ.Elem(), currentPackageName, imports)}
+		return &ast.MapType{Key: typeToExpr(typ.Key(), currentPackagePath, imports),
+			Value: typeToExpr(typ.Elem(), currentPackagePath, imports)}
 	case *types.Chan:
 		return &ast.ChanType{Dir: ast.ChanDir(typ.Dir()),
-			Value: typeToExpr(typ.Elem(), currentPackageName, imports)}
+			Value: typeToExpr(typ.Elem(), currentPackagePath, imports)}
 	case *types.Signature:
 		// This case is for function types (e.g., func(...) (...))
 		params := &ast.FieldList{}
@@ -56,9 +60,13 @@ func typeToExpr(t types.Type, currentPackageName string, imports map[string]stri
 				if param.Name() != "" {
 					paramNames = append(paramNames, ast.NewIdent(param.Name()))
 				}
+				paramType := typeToExpr(param.Type(), currentPackagePath, imports)
+				if typ.Variadic() && i == typ.Params().Len()-1 {
+					paramType = &ast.Ellipsis{Elt: paramType.(*ast.ArrayType).Elt}
+				}
 				params.List = append(params.List, &ast.Field{
 					Names: paramNames,
-					Type:  typeToExpr(param.Type(), currentPackageName, imports),
+					Type:  paramType,
 				})
 			}
 		}
@@ -73,19 +81,76 @@ func typeToExpr(t types.Type, currentPackageName string, imports map[string]stri
 				}
 				results.List = append(results.List, &ast.Field{
 					Names: resultNames,
-					Type:  typeToExpr(result.Type(), currentPackageName, imports),
+					Type:  typeToExpr(result.Type(), currentPackagePath, imports),
 				})
 			}
 		}
 		return &ast.FuncType{Params: params, Results: results}
 	case *types.Interface:
-		// If it's the empty interface, return an empty interface literal
-		if typ.Empty() {
-			return &ast.InterfaceType{Methods: &ast.FieldList{}} // represents interface{}
+		// Constraints written inline, as in [T ~int | ~float64], are implicit interfaces
+		// around a single union and are rendered without the interface{} wrapper
+		if typ.IsImplicit() && typ.NumEmbeddeds() == 1 {
+			return typeToExpr(typ.EmbeddedType(0), currentPackagePath, imports)
 		}
-		// For other interfaces, fall back to its string representation
-		return ast.NewIdent(typ.String())
-
+		// Embedded interfaces and type sets are listed first, followed by the explicitly declared methods
+		methods := &ast.FieldList{}
+		for i := 0; i < typ.NumEmbeddeds(); i++ {
+			methods.List = append(methods.List, &ast.Field{
+				Type: typeToExpr(typ.EmbeddedType(i), currentPackagePath, imports),
+			})
+		}
+		for i := 0; i < typ.NumExplicitMethods(); i++ {
+			method := typ.ExplicitMethod(i)
+			methods.List = append(methods.List, &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(method.Name())},
+				Type:  typeToExpr(method.Type(), currentPackagePath, imports),
+			})
+		}
+		return &ast.InterfaceType{Methods: methods}
+	case *types.Union:
+		// Type set terms, e.g. ~int | ~float64
+		var expr ast.Expr
+		for i := 0; i < typ.Len(); i++ {
+			term := typ.Term(i)
+			termExpr := typeToExpr(term.Type(), currentPackagePath, imports)
+			if term.Tilde() {
+				termExpr = &ast.UnaryExpr{Op: token.TILDE, X: termExpr}
+			}
+			if expr == nil {
+				expr = termExpr
+			} else {
+				expr = &ast.BinaryExpr{X: expr, Op: token.OR, Y: termExpr}
+			}
+		}
+		return expr
+	case *types.Struct:
+		fields := &ast.FieldList{}
+		for i := 0; i < typ.NumFields(); i++ {
+			field := typ.Field(i)
+			astField := &ast.Field{Type: typeToExpr(field.Type(), currentPackagePath, imports)}
+			if !field.Embedded() {
+				astField.Names = []*ast.Ident{ast.NewIdent(field.Name())}
+			}
+			if tag := typ.Tag(i); tag != "" {
+				astField.Tag = &ast.BasicLit{Kind: token.STRING, Value: quoteTag(tag)}
+			}
+			fields.List = append(fields.List, astField)
+		}
+		return &ast.StructType{Fields: fields}
+	case *types.Alias:
+		// Aliases such as any are rendered by name, qualified like named types
+		var nameExpr ast.Expr = ast.NewIdent(typ.Obj().Name())
+		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != currentPackagePath {
+			pkgName, ok := imports[typ.Obj().Pkg().Path()]
+			if !ok {
+				pkgName = typ.Obj().Pkg().Name()
+			}
+			nameExpr = &ast.SelectorExpr{
+				X:   ast.NewIdent(pkgName),
+				Sel: ast.NewIdent(typ.Obj().Name()),
+			}
+		}
+		return instantiateExpr(nameExpr, typ.TypeArgs(), currentPackagePath, imports)
 	case *types.TypeParam:
 		return ast.NewIdent(typ.Obj().Name())
 	default:
@@ -94,6 +159,130 @@ func typeToExpr(t types.Type, currentPackageName string, imports map[string]stri
 	}
 }
 
+// instantiateExpr applies typeArgs to a generic type name, producing X[A] or X[A, B].
+// It returns nameExpr unchanged when there are no type arguments.
+func instantiateExpr(nameExpr ast.Expr,
+	typeArgs *types.TypeList,
+	currentPackagePath string,
+	imports map[string]string) ast.Expr {
+	if typeArgs.Len() == 0 {
+		return nameExpr
+	}
+	var indices []ast.Expr
+	for i := 0; i < typeArgs.Len(); i++ {
+		indices = append(indices, typeToExpr(typeArgs.At(i), currentPackagePath, imports))
+	}
+	if len(indices) == 1 {
+		return &ast.IndexExpr{X: nameExpr, Index: indices[0]}
+	}
+	return &ast.IndexListExpr{X: nameExpr, Indices: indices}
+}
+
+// quoteTag renders a struct tag as a raw string literal where possible.
+func quoteTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// paramTypeExpr converts a parameter's type to an ast.Expr for use in a signature,
+// rendering a variadic []T parameter as ...T.
+func paramTypeExpr(p ParamData, currentPackagePath string, imports map[string]string) ast.Expr {
+	expr := typeToExpr(p.Type, currentPackagePath, imports)
+	if p.Variadic {
+		return &ast.Ellipsis{Elt: expr.(*ast.ArrayType).Elt}
+	}
+	return expr
+}
+
+// callArgs renders the arguments used to forward a call to MethodNameFunc,
+// spreading a variadic final parameter with "...".
+func callArgs(params []ParamData) string {
+	var args []string
+	for _, p := range params {
+		if p.Variadic {
+			args = append(args, p.Name+"...")
+		} else {
+			args = append(args, p.Name)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// paramNames renders the parameters of a method as arguments passed on unchanged, with
+// a variadic final parameter passed as a slice.
+func paramNames(params []ParamData) string {
+	var names []string
+	for _, p := range params {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// prefixed returns s preceded by prefix, or "" if s is empty.
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
+
+// withParamNames returns a copy of methods in which every unnamed or blank ("_")
+// parameter has been given a stable name derived from its type and position,
+// e.g. Write([]byte) becomes Write(byte0 []byte).
+func withParamNames(methods []MethodData, typeParams []ParamData) []MethodData {
+	// Parameters must not shadow the type parameters or builtins the method bodies use
+	shadowed := make(map[string]bool)
+	for _, name := range bodyIdentifiers {
+		shadowed[name] = true
+	}
+	for _, tp := range typeParams {
+		shadowed[tp.Name] = true
+	}
+
+	named := make([]MethodData, len(methods))
+	for i, method := range methods {
+		named[i] = method
+		named[i].Params = make([]ParamData, len(method.Params))
+
+		used := make(map[string]bool)
+		for name := range shadowed {
+			used[name] = true
+		}
+		for _, p := range method.Params {
+			used[p.Name] = true
+		}
+		for j, p := range method.Params {
+			if p.Name == "" || p.Name == "_" {
+				p.Name = uniqueName(fmt.Sprintf("%s%d", lowerFirst(getBaseTypeName(p.Type)), j), used)
+				used[p.Name] = true
+			} else if shadowed[p.Name] {
+				p.Name = uniqueName(p.Name, used)
+				used[p.Name] = true
+			}
+			named[i].Params[j] = p
+		}
+	}
+	return named
+}
+
+// lowerFirst lowercases the leading word of an identifier, treating a run of
+// capitals as an initialism (e.g. "URL" -> "url", "HTTPClient" -> "httpClient").
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
 // getBaseTypeName returns a concise string representation of the base type.
 func getBaseTypeName(t types.Type) string {
 	switch typ := t.(type) {
@@ -116,6 +305,12 @@ func getBaseTypeName(t types.Type) string {
 			return "Interface" // For interface{}
 		}
 		return "Interface"
+	case *types.Struct:
+		return "Struct"
+	case *types.Signature:
+		return "Func"
+	case *types.Alias:
+		return typ.Obj().Name()
 	case *types.TypeParam:
 		return typ.Obj().Name()
 	default:
@@ -123,6 +318,72 @@ func getBaseTypeName(t types.Type) string {
 	}
 }
 
+// lockStmt returns the statement locking a stub for the rest of a method if the stub
+// was created with locking.
+func lockStmt(stub *stubNames) string {
+	return fmt.Sprintf(`if %[1]s.%[2]s {
+		%[1]s.%[3]s.Lock()
+		defer %[1]s.%[3]s.Unlock()
+	}`, stub.Receiver, stub.Locked, stub.Mutex)
+}
+
+// unlockStmt returns the statement unlocking a stub that a method locked without
+// deferring the unlock.
+func unlockStmt(stub *stubNames) string {
+	return fmt.Sprintf(`if %[1]s.%[2]s {
+		%[1]s.%[3]s.Unlock()
+	}`, stub.Receiver, stub.Locked, stub.Mutex)
+}
+
+// readLockStmt returns the statement read-locking a stub for the rest of a method if
+// the stub was created with locking, for methods that only read what it recorded.
+func readLockStmt(stub *stubNames) string {
+	return fmt.Sprintf(`if %[1]s.%[2]s {
+		%[1]s.%[3]s.RLock()
+		defer %[1]s.%[3]s.RUnlock()
+	}`, stub.Receiver, stub.Locked, stub.Mutex)
+}
+
+// declSource gives the source of a declaration the doc comment doc.
+func declSource(doc, src string) string {
+	return doc + "\n" + src
+}
+
+// nodeText renders a declaration or expression built by hand as Go source.
+func nodeText(node ast.Node) string {
+	var buf strings.Builder
+	if err := format.Node(&buf, token.NewFileSet(), node); err != nil {
+		panic(fmt.Errorf("failed to print generated code: %w", err))
+	}
+	return buf.String()
+}
+
+// typeArgsText renders the type parameters of a generic stub as the type arguments
+// instantiating its types within its own methods, e.g. "[K, V]".
+func typeArgsText(typeParams []ParamData) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+	var args []string
+	for _, tp := range typeParams {
+		args = append(args, tp.Name)
+	}
+	return "[" + strings.Join(args, ", ") + "]"
+}
+
+// typeParamsText renders the type parameters of a generic stub as they are declared by
+// its functions, e.g. "[K comparable, V any]".
+func typeParamsText(typeParams []ParamData, currentPackagePath string, imports map[string]string) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+	var params []string
+	for _, field := range copyTypeParams(typeParams, currentPackagePath, imports) {
+		params = append(params, field.Names[0].Name+" "+nodeText(field.Type))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
 // parseStmt parses a string into an ast.Stmt. It assumes the string represents a single statement.
 func parseStmt(stmtStr string) ast.Stmt {
 	fset := token.NewFileSet()
@@ -145,19 +406,125 @@ func parseStmt(stmtStr string) ast.Stmt {
 	return funcDecl.Body.List[0]
 }
 
+// generatedMarker marks files generated by toe, following the convention recognised by
+// linters and code review tools for generated code.
+const generatedMarker = "// Code generated by toe. DO NOT EDIT."
+
+// GenerateHeader generates the comment heading a stub file: the generated-code marker,
+// the interfaces the stubs were generated from and the toe invocation that produced them.
+func GenerateHeader(ifaces []*InterfaceData, invocation string) string {
+	var header strings.Builder
+	header.WriteString(generatedMarker + "\n")
+	for _, ifaceData := range ifaces {
+		fmt.Fprintf(&header, "// Source: %s.%s\n", ifaceData.SourcePackagePath, ifaceData.Name)
+	}
+	if invocation != "" {
+		fmt.Fprintf(&header, "// Invocation: %s\n", invocation)
+	}
+	header.WriteString("\n")
+	return header.String()
+}
+
+// GenerateStubCode generates a file containing the stub for a single interface.
 func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (string, error) {
+	return GenerateStubsCode([]*InterfaceData{ifaceData}, opts)
+}
+
+// GenerateStubsCode generates a single file containing a stub for each of ifaces, which
+// must all be generated into the same package. Imports are shared and resolved across
+// all of the stubs.
+func GenerateStubsCode(ifaces []*InterfaceData, opts *options.StubOptions) (string, error) {
+	if len(ifaces) == 0 {
+		return "", fmt.Errorf("no interfaces to generate stubs for")
+	}
+
 	// Create a new file set and AST file
 	fset := token.NewFileSet()
 	file := &ast.File{
-		Name: ast.NewIdent(ifaceData.PackageName),
+		Name: ast.NewIdent(ifaces[0].PackageName),
+	}
+
+	// Import names must not be shadowed by any identifier used in the stubs' signatures
+	reserved := map[string]bool{ifaces[0].PackageName: true, "opts": true}
+	for _, name := range receiverNames {
+		reserved[name] = true
+	}
+	collected := make(map[string]string)
+	methodsByInterface := make([][]MethodData, len(ifaces))
+	namesByInterface := make([]*stubNames, len(ifaces))
+	for i, ifaceData := range ifaces {
+		if ifaceData.PackagePath != ifaces[0].PackagePath || ifaceData.PackageName != ifaces[0].PackageName {
+			return "", fmt.Errorf("stubs for %s and %s target different packages",
+				ifaces[0].Name,
+				ifaceData.Name)
+		}
+
+		// Unnamed and blank parameters need names before they can be recorded or forwarded
+		methodsByInterface[i] = withParamNames(ifaceData.Methods, ifaceData.TypeParams)
+
+		names, err := resolveStubNames(ifaceData, methodsByInterface[i])
+		if err != nil {
+			return "", err
+		}
+		namesByInterface[i] = names
+
+		for _, tp := range ifaceData.TypeParams {
+			reserved[tp.Name] = true
+		}
+		for _, method := range methodsByInterface[i] {
+			for _, p := range method.Params {
+				reserved[p.Name] = true
+			}
+		}
+		for path, name := range ifaceData.Imports {
+			collected[path] = name
+		}
 	}
+	own := maps.Clone(stubImports)
+	for _, methods := range methodsByInterface {
+		for _, method := range methods {
+			if len(method.Results) > 0 {
+				own[matcherImportPath] = "matcher"
+			}
+			for _, p := range method.Params {
+				if path := cloneImportPath(p.Type); path != "" {
+					own[path] = path
+				}
+			}
+		}
+	}
+	imports := resolveImportNames(own, collected, reserved)
 
-	// Add collected imports
-	importSpecs := []ast.Spec{ // Always import sync for the mutex
-		&ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: `"sync"`}},
-		&ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: `"github.com/phildrip/toe/options"`}},
+	// Every type and function declared by the stubs shares the package scope
+	declared := make(map[string]string)
+	for name := range reserved {
+		declared[name] = name + ", which is used in the stub"
+	}
+	for _, name := range imports {
+		declared[name] = "import " + name
 	}
-	for path, name := range ifaceData.Imports {
+	for i, names := range namesByInterface {
+		topLevel := []string{names.Stub, names.Constructor, names.ConstructorT}
+		for _, method := range methodsByInterface[i] {
+			topLevel = append(topLevel, names.Methods[method.Name].CallType)
+			if len(method.Results) > 0 {
+				topLevel = append(topLevel, names.Methods[method.Name].ReturnsType)
+			}
+		}
+		for _, name := range topLevel {
+			if existing, ok := declared[name]; ok {
+				return "", fmt.Errorf("the stub for %s declares %s, which clashes with %s",
+					ifaces[i].Name,
+					name,
+					existing)
+			}
+			declared[name] = "another declaration in the stub for " + ifaces[i].Name
+		}
+	}
+
+	// Add resolved imports
+	var importSpecs []ast.Spec
+	for path, name := range imports {
 		var importName *ast.Ident
 		// Only add name if it's different from the last part of the path or if it's explicitly needed
 		lastSlash := strings.LastIndex(path, "/")
@@ -178,8 +545,37 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 		Specs: importSpecs,
 	})
 
+	// Generate the code. Declarations built by hand and those written as source are
+	// joined as text and formatted together.
+	var buf strings.Builder
+	if err := format.Node(&buf, fset, file); err != nil {
+		return "", fmt.Errorf("error formatting generated code: %v", err)
+	}
+	for i, ifaceData := range ifaces {
+		for _, decl := range stubDecls(ifaceData, methodsByInterface[i], namesByInterface[i], imports, opts) {
+			buf.WriteString("\n\n" + decl)
+		}
+	}
+
+	code, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", fmt.Errorf("error formatting generated code: %v", err)
+	}
+	return string(code), nil
+}
+
+// stubDecls generates the source of the declarations making up the stub for a single
+// interface: the call and returns types for each method, the stub struct, its
+// constructor and methods.
+func stubDecls(ifaceData *InterfaceData,
+	methods []MethodData,
+	names *stubNames,
+	imports map[string]string,
+	opts *options.StubOptions) []string {
+	var decls []string
+
 	// Create the stub struct definition
-	stubName := "Stub" + ifaceData.Name
+	stubName := names.Stub
 	stubStruct := &ast.TypeSpec{
 		Name: ast.NewIdent(stubName),
 		Type: &ast.StructType{
@@ -187,27 +583,37 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 		},
 	}
 
-	// Add sync.Mutex and _isLocked fields
+	// Add sync.RWMutex and _isLocked fields
 	stubStruct.Type.(*ast.StructType).Fields.List = append(
 		stubStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
-			Names: []*ast.Ident{ast.NewIdent("mu")},
-			Type:  &ast.SelectorExpr{X: ast.NewIdent("sync"), Sel: ast.NewIdent("Mutex")},
+			Names: []*ast.Ident{ast.NewIdent(names.Mutex)},
+			Type:  &ast.SelectorExpr{X: ast.NewIdent(imports[syncImportPath]), Sel: ast.NewIdent("RWMutex")},
 		},
 		&ast.Field{
-			Names: []*ast.Ident{ast.NewIdent("isLocked")},
+			Names: []*ast.Ident{ast.NewIdent(names.Locked)},
 			Type:  ast.NewIdent("bool"),
 		},
+		&ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(names.Options)},
+			Type:  &ast.SelectorExpr{X: ast.NewIdent(imports[optionsImportPath]), Sel: ast.NewIdent("StubOptions")},
+		},
+		&ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(names.Expected)},
+			Type:  &ast.SelectorExpr{X: ast.NewIdent(imports[optionsImportPath]), Sel: ast.NewIdent("Expectations")},
+		},
 	)
 
 	// Add fields for call recording and function stubs to the stub struct
-	for _, method := range ifaceData.Methods {
+	for _, method := range methods {
+		methodNames := names.Methods[method.Name]
+
 		// Add MethodNameFunc field (for lambda stubbing)
 		funcType := &ast.FuncType{}
 		params := &ast.FieldList{}
 		for _, p := range method.Params {
 			params.List = append(params.List, &ast.Field{
 				Names: []*ast.Ident{ast.NewIdent(p.Name)},
-				Type:  typeToExpr(p.Type, ifaceData.PackageName, ifaceData.Imports),
+				Type:  paramTypeExpr(p, ifaceData.PackagePath, imports),
 			})
 		}
 		funcType.Params = params
@@ -215,19 +621,24 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 		results := &ast.FieldList{}
 		for _, r := range method.Results {
 			results.List = append(results.List, &ast.Field{
-				Type: typeToExpr(r.Type, ifaceData.PackageName, ifaceData.Imports),
+				Type: typeToExpr(r.Type, ifaceData.PackagePath, imports),
 			})
 		}
 		funcType.Results = results
 
+		funcField := &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(methodNames.Func)},
+			Type:  funcType,
+		}
+		if method.Origin != "" {
+			// Fields of promoted methods are grouped after the interface's own and marked with their origin
+			funcField.Comment = &ast.CommentGroup{List: []*ast.Comment{{Text: "// from " + method.Origin}}}
+		}
 		stubStruct.Type.(*ast.StructType).Fields.List = append(
-			stubStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
-				Names: []*ast.Ident{ast.NewIdent(method.Name + "Func")},
-				Type:  funcType,
-			})
+			stubStruct.Type.(*ast.StructType).Fields.List, funcField)
 
 		// Add MethodNameCall struct type and its field
-		callStructName := stubName + method.Name + "Call"
+		callStructName := methodNames.CallType
 		callStruct := &ast.TypeSpec{
 			Name: ast.NewIdent(callStructName),
 			Type: &ast.StructType{
@@ -238,22 +649,22 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 		// Add type parameters to call struct if the main struct is generic
 		if len(ifaceData.TypeParams) > 0 {
 			callStruct.TypeParams = &ast.FieldList{List: copyTypeParams(ifaceData.TypeParams,
-				ifaceData.PackageName,
-				ifaceData.Imports)}
+				ifaceData.PackagePath,
+				imports)}
 		}
 
-		for _, p := range method.Params {
+		for j, p := range method.Params {
 			callStruct.Type.(*ast.StructType).Fields.List = append(
 				callStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
-					Names: []*ast.Ident{ast.NewIdent(strings.Title(p.Name))},
-					Type:  typeToExpr(p.Type, ifaceData.PackageName, ifaceData.Imports),
+					Names: []*ast.Ident{ast.NewIdent(methodNames.CallFields[j])},
+					Type:  typeToExpr(p.Type, ifaceData.PackagePath, imports),
 				})
 		}
 
-		file.Decls = append(file.Decls, &ast.GenDecl{
+		decls = append(decls, nodeText(&ast.GenDecl{
 			Tok:   token.TYPE,
 			Specs: []ast.Spec{callStruct},
-		})
+		}))
 
 		// Add MethodNameCalls field (slice of callStructName[T])
 		var callListType ast.Expr = ast.NewIdent(callStructName)
@@ -273,13 +684,13 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 
 		stubStruct.Type.(*ast.StructType).Fields.List = append(
 			stubStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
-				Names: []*ast.Ident{ast.NewIdent(method.Name + "Calls")},
+				Names: []*ast.Ident{ast.NewIdent(methodNames.Calls)},
 				Type:  &ast.ArrayType{Elt: callListType},
 			})
 
 		// Add MethodNameReturns struct type and its field
 		if len(method.Results) > 0 {
-			returnsStructName := stubName + method.Name + "Returns"
+			returnsStructName := methodNames.ReturnsType
 			returnsStruct := &ast.TypeSpec{
 				Name: ast.NewIdent(returnsStructName),
 				Type: &ast.StructType{
@@ -290,29 +701,23 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 			// Add type parameters to returns struct if the main struct is generic
 			if len(ifaceData.TypeParams) > 0 {
 				returnsStruct.TypeParams = &ast.FieldList{List: copyTypeParams(ifaceData.TypeParams,
-					ifaceData.PackageName,
-					ifaceData.Imports)}
+					ifaceData.PackagePath,
+					imports)}
 			}
 
 			for i, res := range method.Results {
-				var fieldName string
-				if res.Name != "" {
-					fieldName = strings.Title(res.Name)
-				} else {
-					baseTypeName := getBaseTypeName(res.Type)
-					fieldName = strings.Title(baseTypeName) + fmt.Sprintf("%d", i)
-				}
+				fieldName := methodNames.ResultFields[i]
 				returnsStruct.Type.(*ast.StructType).Fields.List = append(
 					returnsStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
 						Names: []*ast.Ident{ast.NewIdent(fieldName)},
-						Type:  typeToExpr(res.Type, ifaceData.PackageName, ifaceData.Imports),
+						Type:  typeToExpr(res.Type, ifaceData.PackagePath, imports),
 					})
 			}
 
-			file.Decls = append(file.Decls, &ast.GenDecl{
+			decls = append(decls, nodeText(&ast.GenDecl{
 				Tok:   token.TYPE,
 				Specs: []ast.Spec{returnsStruct},
-			})
+			}))
 
 			// Add MethodNameReturns field to the stub struct
 			var returnsFieldType ast.Expr = ast.NewIdent(returnsStructName)
@@ -332,8 +737,29 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 
 			stubStruct.Type.(*ast.StructType).Fields.List = append(
 				stubStruct.Type.(*ast.StructType).Fields.List, &ast.Field{
-					Names: []*ast.Ident{ast.NewIdent(method.Name + "Returns")},
+					Names: []*ast.Ident{ast.NewIdent(methodNames.Returns)},
 					Type: returnsFieldType,
+				},
+				// Whether MethodNameReturns was set, even to zero values, by its setter
+				&ast.Field{
+					Names: []*ast.Ident{ast.NewIdent(methodNames.ReturnsSet)},
+					Type:  ast.NewIdent("bool"),
+				},
+				// Values for particular calls, which take precedence over MethodNameReturns
+				&ast.Field{
+					Names: []*ast.Ident{ast.NewIdent(methodNames.Sequence)},
+					Type: &ast.IndexExpr{
+						X:     &ast.SelectorExpr{X: ast.NewIdent(imports[optionsImportPath]), Sel: ast.NewIdent("Sequence")},
+						Index: returnsFieldType,
+					},
+				},
+				// Values for matching arguments, which take precedence over everything else
+				&ast.Field{
+					Names: []*ast.Ident{ast.NewIdent(methodNames.Rules)},
+					Type: &ast.IndexExpr{
+						X:     &ast.SelectorExpr{X: ast.NewIdent(imports[matcherImportPath]), Sel: ast.NewIdent("Rules")},
+						Index: returnsFieldType,
+					},
 				})
 
 		}
@@ -342,80 +768,64 @@ func GenerateStubCode(ifaceData *InterfaceData, opts *options.StubOptions) (stri
 
 	// Add type parameters for generic interfaces
 	if len(ifaceData.TypeParams) > 0 {
-		fields := make([]*ast.Field, len(ifaceData.TypeParams))
-		for i, tp := range ifaceData.TypeParams {
-			// Determine the AST representation of the type parameter's constraint
-			var constraintType ast.Expr
-			if tp.Type.String() == "interface{}" { // Now using .String() on types.Type
-				constraintType = &ast.InterfaceType{Methods: &ast.FieldList{}} // Represents 'interface{}'
-			} else {
-				constraintType = typeToExpr(tp.Type, ifaceData.PackageName, ifaceData.Imports)
-			}
-
-			fields[i] = &ast.Field{
-				Names: []*ast.Ident{ast.NewIdent(tp.Name)},
-				Type:  constraintType,
-			}
-		}
-		stubStruct.TypeParams = &ast.FieldList{List: fields}
+		stubStruct.TypeParams = &ast.FieldList{List: copyTypeParams(ifaceData.TypeParams,
+			ifaceData.PackagePath,
+			imports)}
 	}
 
-	file.Decls = append(file.Decls, &ast.GenDecl{ // Changed from decls = append(decls, ...)
+	decls = append(decls, nodeText(&ast.GenDecl{
 		Tok:   token.TYPE,
 		Specs: []ast.Spec{stubStruct},
-	})
+	}))
 
-	// Create constructor
-	file.Decls = append(file.Decls,
-		createConstructor(stubName, ifaceData.TypeParams, ifaceData.PackageName, ifaceData.Imports, opts))
+	// Create constructors
+	decls = append(decls,
+		nodeText(createConstructor(names, ifaceData.TypeParams, ifaceData.PackagePath, imports, opts)),
+		createTestConstructor(names, ifaceData.TypeParams, ifaceData.PackagePath, imports),
+		createVerify(names, methods, ifaceData.TypeParams, imports))
+	decls = append(decls, createReset(names, methods, ifaceData.TypeParams)...)
 
 	// Create methods for the stub struct
-	for _, method := range ifaceData.Methods {
-		file.Decls = append(file.Decls,
-			createMethod(stubName,
+	for _, method := range methods {
+		decls = append(decls,
+			createMethod(names,
 				method,
 				ifaceData.TypeParams,
-				ifaceData.PackageName,
-				ifaceData.Imports,
+				ifaceData.PackagePath,
+				imports,
 				opts))
+		if len(method.Results) > 0 {
+			decls = append(decls, createReturnsHelpers(names, method, ifaceData.TypeParams, imports)...)
+		}
+		decls = append(decls, createExpect(names, method, ifaceData.TypeParams, imports))
+		decls = append(decls, createCallAccessors(names, method, ifaceData.TypeParams, ifaceData.PackagePath, imports)...)
+		decls = append(decls, createMethodReset(names, method, ifaceData.TypeParams))
 	}
 
-	// Generate the code
-	var buf strings.Builder
-	if err := format.Node(&buf, fset, file); err != nil {
-		return "", fmt.Errorf("error formatting generated code: %v", err)
-	}
-
-	return buf.String(), nil
+	return decls
 }
 
 // copyTypeParams creates a new slice of ast.Field representing type parameters.
 // It's used to safely copy type parameters for nested generic structs.
 func copyTypeParams(params []ParamData,
-	currentPackageName string,
+	currentPackagePath string,
 	imports map[string]string) []*ast.Field {
 	copied := make([]*ast.Field, len(params))
 	for i, p := range params {
-		var constraintType ast.Expr
-		if p.Type.String() == "interface{}" {
-			constraintType = &ast.InterfaceType{Methods: &ast.FieldList{}}
-		} else {
-			constraintType = typeToExpr(p.Type, currentPackageName, imports) // Use typeToExpr here
-		}
 		copied[i] = &ast.Field{
 			Names: []*ast.Ident{ast.NewIdent(p.Name)},
-			Type:  constraintType,
+			Type:  typeToExpr(p.Type, currentPackagePath, imports), // Constraints are interfaces
 		}
 	}
 	return copied
 }
 
-func createConstructor(stubName string,
+func createConstructor(names *stubNames,
 	typeParams []ParamData,
-	currentPackageName string,
+	currentPackagePath string,
 	imports map[string]string,
 	opts *options.StubOptions) *ast.FuncDecl {
-	constructorName := "New" + stubName
+	stubName := names.Stub
 
 	// Build receiver type for the constructor
 	var resultType ast.Expr = ast.NewIdent(stubName)
@@ -424,7 +834,7 @@ func createConstructor(stubName string,
 	var funcTypeParams *ast.FieldList
 	if len(typeParams) > 0 {
 		funcTypeParams = &ast.FieldList{List: copyTypeParams(typeParams,
-			currentPackageName,
+			currentPackagePath,
 			imports)}
 
 		var typeArgs []ast.Expr
@@ -439,12 +849,12 @@ func createConstructor(stubName string,
 	}
 
 	return &ast.FuncDecl{
-		Name: ast.NewIdent(constructorName),
+		Name: ast.NewIdent(names.Constructor),
 		Type: &ast.FuncType{
 			TypeParams: funcTypeParams, // Add type parameters to the function declaration
 			Params: &ast.FieldList{List: []*ast.Field{{
-				Names: []*ast.Ident{ast.NewIdent("opts")},
-				Type:  &ast.SelectorExpr{X: ast.NewIdent("options"), Sel: ast.NewIdent("StubOptions")},
+				Names: []*ast.Ident{ast.NewIdent(names.Opts)},
+				Type:  &ast.SelectorExpr{X: ast.NewIdent(imports[optionsImportPath]), Sel: ast.NewIdent("StubOptions")},
 			}}},
 			Results:    &ast.FieldList{List: []*ast.Field{{Type: &ast.StarExpr{X: resultType}}}},
 		},
@@ -457,7 +867,8 @@ func createConstructor(stubName string,
 							X: &ast.CompositeLit{
 								Type: resultType,
 								Elts: []ast.Expr{
-									&ast.KeyValueExpr{Key: ast.NewIdent("isLocked"), Value: ast.NewIdent("opts.WithLocking")},
+									&ast.KeyValueExpr{Key: ast.NewIdent(names.Locked), Value: ast.NewIdent(names.Opts + ".WithLocking")},
+									&ast.KeyValueExpr{Key: ast.NewIdent(names.Options), Value: ast.NewIdent(names.Opts)},
 								},
 							},
 						},
@@ -468,17 +879,21 @@ func createConstructor(stubName string,
 	}
 }
 
-func createMethod(stubName string,
+func createMethod(stub *stubNames,
 	method MethodData,
 	typeParams []ParamData,
-	currentPackageName string,
+	currentPackagePath string,
 	imports map[string]string,
-	opts *options.StubOptions) *ast.FuncDecl {
+	opts *options.StubOptions) string {
+	stubName := stub.Stub
+	names := stub.Methods[method.Name]
+	recvName := stub.Receiver
+
 	// Method receiver
 	recv := &ast.FieldList{
 		List: []*ast.Field{
 			{
-				Names: []*ast.Ident{ast.NewIdent("s")},
+				Names: []*ast.Ident{ast.NewIdent(recvName)},
 				Type:  &ast.StarExpr{X: ast.NewIdent(stubName)},
 			},
 		},
@@ -514,7 +929,7 @@ func createMethod(stubName string,
 	for _, p := range method.Params {
 		params.List = append(params.List, &ast.Field{
 			Names: []*ast.Ident{ast.NewIdent(p.Name)},
-			Type:  typeToExpr(p.Type, currentPackageName, imports),
+			Type:  paramTypeExpr(p, currentPackagePath, imports),
 		})
 	}
 
@@ -522,27 +937,28 @@ func createMethod(stubName string,
 	results := &ast.FieldList{}
 	for _, r := range method.Results {
 		results.List = append(results.List, &ast.Field{
-			Type: typeToExpr(r.Type, currentPackageName, imports),
+			Type: typeToExpr(r.Type, currentPackagePath, imports),
 		})
 	}
 
 	// Method body
 	var bodyStmts []ast.Stmt
 
-	// Add conditional locking. The sync import will now always be used.
+	// Lock only while recording the call and reading what the stub was configured
+	// with, so that MethodNameFunc, which may block, and failures run without holding
+	// the lock. The sync import will now always be used.
 	bodyStmts = append(bodyStmts, parseStmt(fmt.Sprintf(`
-	if s.isLocked {
-		s.mu.Lock()
-		defer s.mu.Unlock()
+	if %[1]s.%[2]s {
+		%[1]s.%[3]s.Lock()
 	}
-	`)))
+	`, recvName, stub.Locked, stub.Mutex)))
 
 	// Add call recording
-	callStructName := stubName + method.Name + "Call"
+	callStructName := names.CallType
 	var callElts []ast.Expr
-	for _, p := range method.Params {
+	for i, p := range method.Params {
 		callElts = append(callElts, &ast.KeyValueExpr{
-			Key:   ast.NewIdent(strings.Title(p.Name)), // Capitalize key for public field
+			Key:   ast.NewIdent(names.CallFields[i]), // Capitalize key for public field
 			Value: ast.NewIdent(p.Name),
 		})
 	}
@@ -571,58 +987,111 @@ func createMethod(stubName string,
 	// Assign the result of append back to the slice
 	bodyStmts = append(bodyStmts, &ast.AssignStmt{
 		Tok: token.ASSIGN,
-		Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("s"),
-			Sel: ast.NewIdent(method.Name + "Calls")}},
+		Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent(recvName),
+			Sel: ast.NewIdent(names.Calls)}},
 		Rhs: []ast.Expr{&ast.CallExpr{
 			Fun: ast.NewIdent("append"),
 			Args: []ast.Expr{
-				&ast.SelectorExpr{X: ast.NewIdent("s"), Sel: ast.NewIdent(method.Name + "Calls")},
+				&ast.SelectorExpr{X: ast.NewIdent(recvName), Sel: ast.NewIdent(names.Calls)},
 				callInstance,
 			},
 		}},
 	})
 
+	bodyStmts = append(bodyStmts, parseStmt(fmt.Sprintf("%s := %s.%s", stub.Func, recvName, names.Func)))
+
+	// Generate string for MethodNameFunc call args
+	funcCallArgsStr := callArgs(method.Params)
+
 	// Handle return values
 	if len(method.Results) > 0 { // Only if the method has return values
-		// If MethodNameFunc is set, use it.
-		funcName := method.Name + "Func"
-		returnsName := method.Name + "Returns"
-		// Generate string for MethodNameFunc call args
-		var funcCallArgs []string
-		for _, p := range method.Params {
-			funcCallArgs = append(funcCallArgs, p.Name)
-		}
-		funcCallArgsStr := strings.Join(funcCallArgs, ", ")
-
-		// Construct the if-else logic as a string and parse it into an AST statement
-		var returnValues []string
-		for i, r := range method.Results {
-			var fieldName string
-			if r.Name != "" {
-				fieldName = strings.Title(r.Name)
-			} else {
-				baseTypeName := getBaseTypeName(r.Type)
-				fieldName = strings.Title(baseTypeName) + fmt.Sprintf("%d", i)
+		// Every set of return values is held in a MethodNameReturns struct
+		returnValues := func(values string) string {
+			var fields []string
+			for _, fieldName := range names.ResultFields {
+				fields = append(fields, fmt.Sprintf("%s.%s", values, fieldName))
 			}
-			returnValues = append(returnValues, fmt.Sprintf("s.%s.%s", returnsName, fieldName))
+			return strings.Join(fields, ", ")
 		}
-		returnValuesStr := strings.Join(returnValues, ", ")
 
-		returnLogicStr := fmt.Sprintf(`
-		if s.%s != nil {
-			return s.%s(%s)
-		} else {
-			return %s
+		// Values sequenced for this call take precedence over MethodNameReturns, and a
+		// strict stub fails if there are neither
+		bodyStmts = append(bodyStmts,
+			parseStmt(fmt.Sprintf("%[1]s := %[2]s.%[3]s.Strict && %[2]s.%[4]s.Empty() && !%[2]s.%[7]s && %[5]s.IsZero(%[2]s.%[6]s)",
+				stub.Unexpected,
+				recvName,
+				stub.Options,
+				names.Sequence,
+				imports[optionsImportPath],
+				names.Returns,
+				names.ReturnsSet)),
+			parseStmt(fmt.Sprintf("%[1]s, %[2]s := %[3]s.%[4]s.ForCall(len(%[3]s.%[5]s)-1, %[3]s.%[6]s, %[3]s.%[7]s.WhenExhausted)",
+				stub.Result,
+				stub.Sequenced,
+				recvName,
+				names.Sequence,
+				names.Calls,
+				names.Returns,
+				stub.Options)))
+		bodyStmts = append(bodyStmts, parseStmt(unlockStmt(stub)))
+
+		// Values of the first rule matching the arguments take precedence over
+		// everything. Rules guard themselves, so are matched without the stub's lock.
+		bodyStmts = append(bodyStmts, parseStmt(fmt.Sprintf(`
+		if %[1]s, ok := %[2]s.%[3]s.Match(%[4]s); ok {
+			return %[5]s
 		}
 		`,
-			funcName,
-			funcName,
-			funcCallArgsStr,
-			returnValuesStr)
+			stub.Matched,
+			recvName,
+			names.Rules,
+			paramNames(method.Params),
+			returnValues(stub.Matched))))
 
-		bodyStmts = append(bodyStmts, parseStmt(returnLogicStr))
+		bodyStmts = append(bodyStmts,
+			parseStmt(fmt.Sprintf(`
+			if %[1]s != nil {
+				return %[1]s(%[2]s)
+			}
+			`, stub.Func, funcCallArgsStr)),
+			parseStmt(fmt.Sprintf(`
+			if %[1]s {
+				%[2]s.%[3]s.Unexpected(%[4]q%[5]s)
+			}
+			`,
+				stub.Unexpected,
+				recvName,
+				stub.Options,
+				stubName+"."+method.Name,
+				prefixed(", ", paramNames(method.Params)))),
+			parseStmt(fmt.Sprintf(`
+			if !%[1]s {
+				%[2]s.%[3]s.Fail(%[4]q)
+			}
+			`,
+				stub.Sequenced,
+				recvName,
+				stub.Options,
+				fmt.Sprintf("%s.%s called more times than it has sequenced return values", stubName, method.Name))),
+			&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent(returnValues(stub.Result))}})
 
-	} else { // No return values in method signature, just add a simple return
+	} else { // No return values in method signature, just forward to MethodNameFunc if set
+		// A strict stub fails the call unless MethodNameFunc is set or calls are expected
+		bodyStmts = append(bodyStmts, parseStmt(unlockStmt(stub)))
+		bodyStmts = append(bodyStmts, parseStmt(fmt.Sprintf(`
+		if %[1]s != nil {
+			%[1]s(%[2]s)
+		} else if %[3]s.%[4]s.Strict && !%[3]s.%[5]s.Allows(%[6]q) {
+			%[3]s.%[4]s.Unexpected(%[6]q%[7]s)
+		}
+		`,
+			stub.Func,
+			funcCallArgsStr,
+			recvName,
+			stub.Options,
+			stub.Expected,
+			stubName+"."+method.Name,
+			prefixed(", ", paramNames(method.Params)))))
 		bodyStmts = append(bodyStmts, &ast.ReturnStmt{})
 	}
 
@@ -630,7 +1099,7 @@ func createMethod(stubName string,
 		List: bodyStmts,
 	}
 
-	return &ast.FuncDecl{
+	decl := nodeText(&ast.FuncDecl{
 		Recv: recv,
 		Name: ast.NewIdent(method.Name),
 		Type: &ast.FuncType{
@@ -638,5 +1107,337 @@ func createMethod(stubName string,
 			Results: results,
 		},
 		Body: tbody,
+	})
+	if method.Origin == "" {
+		return decl
+	}
+	return declSource(fmt.Sprintf("// %s implements the method promoted from the embedded %s.", method.Name, method.Origin), decl)
+}
+
+// createReturnsHelpers creates the methods setting the return values of a method with
+// results: MethodNameReturnsOnCall sets the values of a single call,
+// MethodNameReturnsSequence those of the calls still to come and WhenMethodName those of
+// calls with matching arguments.
+func createReturnsHelpers(stub *stubNames,
+	method MethodData,
+	typeParams []ParamData,
+	imports map[string]string) []string {
+	names := stub.Methods[method.Name]
+	recvType := stub.Stub + typeArgsText(typeParams)
+	returnsType := names.ReturnsType + typeArgsText(typeParams)
+
+	set := declSource(
+		fmt.Sprintf("// %s sets the values returned by calls to %s. Unlike assigning\n"+
+			"// %s, it configures a strict stub even with zero values.",
+			names.SetReturns,
+			method.Name,
+			names.Returns),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s %[5]s) {
+			%[6]s
+			%[1]s.%[7]s = %[4]s
+			%[1]s.%[8]s = true
+		}`,
+			stub.Receiver,
+			recvType,
+			names.SetReturns,
+			stub.Values,
+			returnsType,
+			lockStmt(stub),
+			names.Returns,
+			names.ReturnsSet))
+	onCall := declSource(
+		fmt.Sprintf("// %s sets the values returned by the %s-th call to %s, counting from 0.",
+			names.ReturnsOnCall,
+			stub.Call,
+			method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s int, %[5]s %[6]s) {
+			%[7]s
+			%[1]s.%[8]s.Set(%[4]s, %[5]s)
+		}`,
+			stub.Receiver,
+			recvType,
+			names.ReturnsOnCall,
+			stub.Call,
+			stub.Values,
+			returnsType,
+			lockStmt(stub),
+			names.Sequence))
+	sequence := declSource(
+		fmt.Sprintf("// %s sets the values returned by the next calls to %s in turn, replacing any set before.",
+			names.ReturnsSequence,
+			method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s ...%[5]s) {
+			%[6]s
+			%[1]s.%[7]s.Replace(len(%[1]s.%[8]s), %[4]s)
+		}`,
+			stub.Receiver,
+			recvType,
+			names.ReturnsSequence,
+			stub.Values,
+			returnsType,
+			lockStmt(stub),
+			names.Sequence,
+			names.Calls))
+
+	// Rules guard themselves, so need not take the stub's lock
+	var matchers []string
+	for _, p := range method.Params {
+		matchers = append(matchers, p.Name+" "+imports[matcherImportPath]+".Matcher")
 	}
+	when := declSource(
+		fmt.Sprintf("// %s adds a rule making calls to %s whose arguments match the given matchers return\n"+
+			"// the values passed to its Return method. The first matching rule takes precedence over\n"+
+			"// all other return values.",
+			names.When,
+			method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s) *%[5]s.Rule[%[6]s] {
+			return %[1]s.%[7]s.Add(%[8]s)
+		}`,
+			stub.Receiver,
+			recvType,
+			names.When,
+			strings.Join(matchers, ", "),
+			imports[matcherImportPath],
+			returnsType,
+			names.Rules,
+			paramNames(method.Params)))
+	return []string{set, onCall, sequence, when}
+}
+
+// createTestConstructor creates the constructor of a stub for use in a single test,
+// which fails the test rather than panicking and verifies the calls expected of the stub
+// when the test ends.
+func createTestConstructor(names *stubNames,
+	typeParams []ParamData,
+	currentPackagePath string,
+	imports map[string]string) string {
+	return declSource(
+		fmt.Sprintf("// %s returns a stub that reports failed calls with %s and verifies the calls\n"+
+			"// expected of it when %[2]s's test ends.",
+			names.ConstructorT,
+			names.T),
+		fmt.Sprintf(`func %[1]s%[11]s(%[2]s %[3]s.TB, %[4]s %[5]s.StubOptions) *%[6]s {
+			%[4]s.T = %[2]s
+			%[7]s := %[8]s%[9]s(%[4]s)
+			%[2]s.Cleanup(func() {
+				%[7]s.%[10]s(%[2]s)
+			})
+			return %[7]s
+		}`,
+			names.ConstructorT,
+			names.T,
+			imports[testingImportPath],
+			names.Opts,
+			imports[optionsImportPath],
+			names.Stub+typeArgsText(typeParams),
+			names.Receiver,
+			names.Constructor,
+			typeArgsText(typeParams),
+			names.Verify,
+			typeParamsText(typeParams, currentPackagePath, imports)))
+}
+
+// createVerify creates the method reporting each expected number of calls a stub has not
+// received.
+func createVerify(stub *stubNames, methods []MethodData, typeParams []ParamData, imports map[string]string) string {
+	var counts []string
+	for _, method := range methods {
+		counts = append(counts, fmt.Sprintf("calls[%q] = len(%s.%s)",
+			stub.Stub+"."+method.Name,
+			stub.Receiver,
+			stub.Methods[method.Name].Calls))
+	}
+	return declSource(
+		fmt.Sprintf("// %s reports each expected number of calls the stub has not received with\n"+
+			"// %s.Errorf.",
+			stub.Verify,
+			stub.T),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s %[5]s.TB) {
+			%[4]s.Helper()
+			%[6]s
+			calls := make(map[string]int)
+			%[8]s
+			%[1]s.%[7]s.Verify(%[4]s, calls)
+		}`,
+			stub.Receiver,
+			stub.Stub+typeArgsText(typeParams),
+			stub.Verify,
+			stub.T,
+			imports[testingImportPath],
+			readLockStmt(stub),
+			stub.Expected,
+			strings.Join(counts, "\n")))
+}
+
+// createExpect creates the method setting the number of calls expected of a method.
+func createExpect(stub *stubNames, method MethodData, typeParams []ParamData, imports map[string]string) string {
+	names := stub.Methods[method.Name]
+	return declSource(
+		fmt.Sprintf("// %s returns a new expectation of calls to %s, which is verified by %s.",
+			names.Expect,
+			method.Name,
+			stub.Verify),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() *%[4]s.Expectation {
+			return %[1]s.%[5]s.Expect(%[6]q)
+		}`,
+			stub.Receiver,
+			stub.Stub+typeArgsText(typeParams),
+			names.Expect,
+			imports[optionsImportPath],
+			stub.Expected,
+			stub.Stub+"."+method.Name))
+}
+
+// createCallAccessors creates the methods reading the calls recorded for a method, which
+// take the stub's lock so that they are safe to use while other goroutines call it.
+func createCallAccessors(stub *stubNames,
+	method MethodData,
+	typeParams []ParamData,
+	currentPackagePath string,
+	imports map[string]string) []string {
+	names := stub.Methods[method.Name]
+	recvType := stub.Stub + typeArgsText(typeParams)
+	callType := names.CallType + typeArgsText(typeParams)
+
+	count := declSource(
+		fmt.Sprintf("// %s returns the number of calls to %s so far.", names.CallCount, method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() int {
+			%[4]s
+			return len(%[1]s.%[5]s)
+		}`,
+			stub.Receiver,
+			recvType,
+			names.CallCount,
+			readLockStmt(stub),
+			names.Calls))
+	snapshot := declSource(
+		fmt.Sprintf("// %s returns a copy of the calls to %s so far.", names.CallsSnapshot, method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() []%[6]s {
+			%[4]s
+			return append([]%[6]s(nil), %[1]s.%[5]s...)
+		}`,
+			stub.Receiver,
+			recvType,
+			names.CallsSnapshot,
+			readLockStmt(stub),
+			names.Calls,
+			callType))
+	if len(method.Params) == 0 {
+		return []string{count, snapshot}
+	}
+
+	// Recorded slices and maps must not be shared with the caller
+	doc := fmt.Sprintf("// %s returns the arguments of the %s-th call to %s, counting from 0.",
+		names.CallArgs,
+		stub.Call,
+		method.Name)
+	var args, results []string
+	for i, p := range method.Params {
+		arg := fmt.Sprintf("%s.%s[%s].%s", stub.Receiver, names.Calls, stub.Call, names.CallFields[i])
+		if path := cloneImportPath(p.Type); path != "" {
+			arg = fmt.Sprintf("%s.Clone(%s)", imports[path], arg)
+			doc = fmt.Sprintf("// %s returns the arguments of the %s-th call to %s, counting from 0.\n"+
+				"// Slices and maps are copied, but share the values they hold with the call.",
+				names.CallArgs,
+				stub.Call,
+				method.Name)
+		}
+		args = append(args, arg)
+		results = append(results, nodeText(typeToExpr(p.Type, currentPackagePath, imports)))
+	}
+	callArgs := declSource(
+		doc,
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s(%[4]s int) (%[7]s) {
+			%[5]s
+			return %[6]s
+		}`,
+			stub.Receiver,
+			recvType,
+			names.CallArgs,
+			stub.Call,
+			readLockStmt(stub),
+			strings.Join(args, ", "),
+			strings.Join(results, ", ")))
+	return []string{count, callArgs, snapshot}
+}
+
+// resetStmts returns the statements, run under the stub's lock, that forget the calls to
+// a method, everything configured for it and the calls expected of it.
+func resetStmts(stub *stubNames, method MethodData, typeParams []ParamData) []string {
+	names := stub.Methods[method.Name]
+	stmts := []string{
+		fmt.Sprintf("%s.%s = nil", stub.Receiver, names.Func),
+		fmt.Sprintf("%s.%s = nil", stub.Receiver, names.Calls),
+	}
+	if len(method.Results) > 0 {
+		stmts = append(stmts,
+			fmt.Sprintf("%s.%s = %s{}", stub.Receiver, names.Returns, names.ReturnsType+typeArgsText(typeParams)),
+			fmt.Sprintf("%s.%s = false", stub.Receiver, names.ReturnsSet),
+			fmt.Sprintf("%s.%s.Reset()", stub.Receiver, names.Sequence),
+			fmt.Sprintf("%s.%s.Reset()", stub.Receiver, names.Rules))
+	}
+	return append(stmts, fmt.Sprintf("%s.%s.Forget(%q)", stub.Receiver, stub.Expected, stub.Stub+"."+method.Name))
+}
+
+// createReset creates the methods resetting every method of a stub at once, so that one
+// stub can be reused between tests.
+func createReset(stub *stubNames, methods []MethodData, typeParams []ParamData) []string {
+	var resets, calls []string
+	for _, method := range methods {
+		names := stub.Methods[method.Name]
+		resets = append(resets, resetStmts(stub, method, typeParams)...)
+		if len(method.Results) > 0 {
+			calls = append(calls, fmt.Sprintf("%[1]s.%[2]s.Rebase(len(%[1]s.%[3]s))", stub.Receiver, names.Sequence, names.Calls))
+		}
+		calls = append(calls, fmt.Sprintf("%s.%s = nil", stub.Receiver, names.Calls))
+	}
+	recvType := stub.Stub + typeArgsText(typeParams)
+	reset := declSource(
+		fmt.Sprintf("// %s forgets the calls to every method, everything the stub was configured with\n"+
+			"// and the calls expected of it. The options it was created with are kept.",
+			stub.Reset),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() {
+			%[4]s
+			%[5]s
+		}`,
+			stub.Receiver,
+			recvType,
+			stub.Reset,
+			lockStmt(stub),
+			strings.Join(resets, "\n")))
+	resetCalls := declSource(
+		fmt.Sprintf("// %s forgets the calls to every method, keeping everything the stub was\n"+
+			"// configured with. Calls are numbered from 0 again, and sequences continue with\n"+
+			"// the next call.",
+			stub.ResetCalls),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() {
+			%[4]s
+			%[5]s
+		}`,
+			stub.Receiver,
+			recvType,
+			stub.ResetCalls,
+			lockStmt(stub),
+			strings.Join(calls, "\n")))
+	return []string{reset, resetCalls}
+}
+
+// createMethodReset creates the method forgetting the calls to a method, everything
+// configured for it and the calls expected of it.
+func createMethodReset(stub *stubNames, method MethodData, typeParams []ParamData) string {
+	names := stub.Methods[method.Name]
+	return declSource(
+		fmt.Sprintf("// %s forgets the calls to %s, everything configured for it and the calls\n"+
+			"// expected of it.",
+			names.Reset,
+			method.Name),
+		fmt.Sprintf(`func (%[1]s *%[2]s) %[3]s() {
+			%[4]s
+			%[5]s
+		}`,
+			stub.Receiver,
+			stub.Stub+typeArgsText(typeParams),
+			names.Reset,
+			lockStmt(stub),
+			strings.Join(resetStmts(stub, method, typeParams), "\n")))
 }
@@ -2,10 +2,9 @@ module github.com/phildrip/toe
 
 go 1.24.0
 
-require golang.org/x/tools v0.34.0
-
-
 require (
-	golang.org/x/mod v0.25.0 // indirect
-	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/mod v0.25.0
+	golang.org/x/tools v0.34.0
 )
+
+require golang.org/x/sync v0.15.0 // indirect
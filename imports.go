@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Import paths that every generated stub depends on.
+const (
+	syncImportPath    = "sync"
+	testingImportPath = "testing"
+	optionsImportPath = "github.com/phildrip/toe/options"
+)
+
+// matcherImportPath is imported by stubs with any method with results.
+const matcherImportPath = "github.com/phildrip/toe/matcher"
+
+// Import paths of the packages cloning slice and map arguments, imported by stubs with
+// any parameter of such a type.
+const (
+	slicesImportPath = "slices"
+	mapsImportPath   = "maps"
+)
+
+// cloneImportPath returns the import path of the package whose Clone function copies a
+// value of type t, or "" if values of t are not cloned.
+func cloneImportPath(t types.Type) string {
+	switch t.Underlying().(type) {
+	case *types.Slice:
+		return slicesImportPath
+	case *types.Map:
+		return mapsImportPath
+	}
+	return ""
+}
+
+// stubImports maps the import paths the stub itself always uses to their package names.
+var stubImports = map[string]string{
+	syncImportPath:    "sync",
+	testingImportPath: "testing",
+	optionsImportPath: "options",
+}
+
+// resolveImportNames assigns every import path a local name that is unique within the
+// generated file and does not collide with any reserved identifier (the stub package
+// name, parameter names and so on). The stub's own imports, own, are named first so
+// that a package sharing their name is the one that gets aliased; the remaining paths
+// are named in sorted order, which keeps the chosen aliases deterministic.
+//
+// Aliases are tried in order: the package name, the parent path element joined with the
+// package name (e.g. "appsv1" for k8s.io/api/apps/v1), then the package name with an
+// increasing numeric suffix.
+func resolveImportNames(own, collected map[string]string, reserved map[string]bool) map[string]string {
+	taken := make(map[string]bool, len(reserved))
+	for name := range reserved {
+		taken[name] = true
+	}
+
+	resolved := make(map[string]string, len(own)+len(collected))
+	assign := func(paths []string, names map[string]string) {
+		sort.Strings(paths)
+		for _, importPath := range paths {
+			if _, ok := resolved[importPath]; ok {
+				continue
+			}
+			name := uniqueImportName(importPath, names[importPath], taken)
+			taken[name] = true
+			resolved[importPath] = name
+		}
+	}
+
+	var fixedPaths, collectedPaths []string
+	for importPath := range own {
+		fixedPaths = append(fixedPaths, importPath)
+	}
+	for importPath := range collected {
+		collectedPaths = append(collectedPaths, importPath)
+	}
+	assign(fixedPaths, own)
+	assign(collectedPaths, collected)
+
+	return resolved
+}
+
+// uniqueImportName picks the first candidate alias for importPath that is not taken.
+func uniqueImportName(importPath, pkgName string, taken map[string]bool) string {
+	if !taken[pkgName] {
+		return pkgName
+	}
+	if parent := identifierPart(path.Base(path.Dir(importPath))); parent != "" {
+		if name := parent + pkgName; !taken[name] {
+			return name
+		}
+	}
+	for i := 2; ; i++ {
+		if name := fmt.Sprintf("%s%d", pkgName, i); !taken[name] {
+			return name
+		}
+	}
+}
+
+// identifierPart lowercases s and strips characters that cannot appear in an identifier,
+// returning "" if nothing usable remains.
+func identifierPart(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || (b.Len() > 0 && unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"go/format"
@@ -10,13 +12,22 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	"github.com/phildrip/toe/options"
+	"golang.org/x/tools/go/packages"
 )
 
 func run(stdout, stderr io.Writer, args []string) int {
 	var stubDirFlag string
 	var outputFile string // Keep outputFile as a flag
+	var allFlag bool
+	var combineFlag bool
+	var configFlag string
+	var checkFlag bool
+	var dryRunFlag bool
+	var forceFlag bool
+	var naming StubNaming
 
 	fs := flag.NewFlagSet("toe", flag.ContinueOnError)
 	fs.SetOutput(stderr) // Direct flag errors to stderr
@@ -27,89 +38,478 @@ func run(stdout, stderr io.Writer, args []string) int {
 	fs.StringVar(&outputFile,
 		"o",
 		"",
-		"output file name (if not provided, defaults to stub_<interface-lowercased>.go in the default/specified stub-dir)")
+		"output file name, or - for stdout (if not provided, defaults to stub_<interface-lowercased>.go in the default/specified stub-dir)")
+	fs.BoolVar(&allFlag,
+		"all",
+		false,
+		"generate stubs for every exported interface in the input directory")
+	fs.BoolVar(&combineFlag,
+		"combine",
+		false,
+		"write all stubs to a single file (defaults to stub_<package>.go in the stub-dir)")
+	fs.StringVar(&configFlag,
+		"config",
+		"",
+		"generate the stubs listed in a config file (defaults to "+configFileName+" at the module root when no arguments are given)")
+	fs.BoolVar(&checkFlag,
+		"check",
+		false,
+		"report stubs that are missing or out of date as a unified diff instead of writing them, and exit non-zero if any are found")
+	fs.BoolVar(&dryRunFlag,
+		"dry-run",
+		false,
+		"report the stub files that would be created or changed without writing them")
+	fs.BoolVar(&forceFlag,
+		"force",
+		false,
+		"overwrite existing files even if they were not generated by toe")
+	fs.StringVar(&naming.Stub,
+		"stub-name",
+		"",
+		"template for the stub type name (default \""+DefaultStubNaming.Stub+"\")")
+	fs.StringVar(&naming.Constructor,
+		"constructor-name",
+		"",
+		"template for the constructor name (default \""+DefaultStubNaming.Constructor+"\")")
+	fs.StringVar(&naming.Func,
+		"func-name",
+		"",
+		"template for the field holding a method's function (default \""+DefaultStubNaming.Func+"\")")
+	fs.StringVar(&naming.Calls,
+		"calls-name",
+		"",
+		"template for the field recording a method's calls (default \""+DefaultStubNaming.Calls+"\")")
+	fs.StringVar(&naming.Returns,
+		"returns-name",
+		"",
+		"template for the field holding a method's return values (default \""+DefaultStubNaming.Returns+"\")")
+	fs.StringVar(&naming.CallType,
+		"call-type-name",
+		"",
+		"template for the type of a recorded call (default \""+DefaultStubNaming.CallType+"\")")
+	fs.StringVar(&naming.ReturnsType,
+		"returns-type-name",
+		"",
+		"template for the type of a method's return values (default \""+DefaultStubNaming.ReturnsType+"\")")
+	fs.StringVar(&naming.Results,
+		"result-names",
+		"",
+		"how the fields of a method's return values are named: type, index or named (default \""+DefaultStubNaming.Results+"\")")
 
 	// Parse command-line arguments, excluding the program name
 	if err := fs.Parse(args[1:]); err != nil {
 		return 1
 	}
 
-	if fs.NArg() != 2 {
-		fmt.Fprintf(stderr,
-			"Usage: %s [-test-package] [-stub-dir <dir>] [-o <output.go>] <input_directory> <interface>\n",
-			args[0])
+	output := outputOptions{Check: checkFlag, DryRun: dryRunFlag, Stdout: outputFile == "-", Force: forceFlag}
+	invocation := toeInvocation(fs)
+	if output.Stdout {
+		// The stub is generated as if written to its default location in the stub-dir
+		outputFile = ""
+	}
+	if (output.Check && output.DryRun) || (output.Stdout && (output.Check || output.DryRun)) {
+		fmt.Fprintf(stderr, "Error: only one of -check, -dry-run and -o - may be given\n")
 		return 1
 	}
 
-	// Get inputDir and interfaceName now that flags are parsed
-	inputDir := fs.Arg(0)
-	interfaceName := fs.Arg(1)
+	// Without arguments every stub listed in the config file is regenerated
+	if configFlag != "" || (fs.NArg() == 0 && !allFlag) {
+		generationFlagSet := false
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "config", "check", "dry-run", "force":
+			default:
+				generationFlagSet = true
+			}
+		})
+		if fs.NArg() > 0 || generationFlagSet {
+			fmt.Fprintf(stderr, "Error: the stubs to generate are taken from the config file; only -check, -dry-run or -force may also be given\n")
+			return 1
+		}
+		if configFlag == "" {
+			// Without a config file, running toe without arguments is most likely a
+			// request for help
+			var err error
+			if configFlag, err = findConfig("."); err != nil {
+				fmt.Fprintf(stderr, "Error finding config: %v\n", err)
+				printUsage(stderr, args[0])
+				return 1
+			}
+		}
+		return runConfig(stdout, stderr, configFlag, invocation, output)
+	}
+
+	// A fully-qualified selector such as net/http.RoundTripper names both the package
+	// and the interface, so every positional argument is then a selector
+	_, _, selectorMode := parseInterfaceSelector(fs.Arg(0))
+	if info, err := os.Stat(fs.Arg(0)); err == nil && info.IsDir() {
+		selectorMode = false
+	}
+
+	if (selectorMode && (allFlag || fs.NArg() < 1)) ||
+		(!selectorMode && allFlag && fs.NArg() != 1) ||
+		(!selectorMode && !allFlag && fs.NArg() < 2) {
+		printUsage(stderr, args[0])
+		return 1
+	}
 
-	// Determine the stub directory and filename
+	// Determine the stub directory
 	actualStubDir := stubDirFlag
 	if actualStubDir == "" {
 		actualStubDir = "stubs"
 	}
 
-	outputFilename := fmt.Sprintf("stub_%s.go", strings.ToLower(interfaceName))
+	layout := stubLayout{
+		StubDir:    actualStubDir,
+		OutputFile: outputFile,
+		Combine:    combineFlag,
+		Naming:     naming,
+	}
 
-	// If -o is not provided, derive it from stubDir and interface name
-	if outputFile == "" {
-		outputFile = filepath.Join(actualStubDir, outputFilename)
+	// An existing directory is loaded as a single package with stubs placed relative to
+	// the working directory, as are the packages named by selectors, which are resolved
+	// through the module graph. Anything else is a package pattern, such as ./... or an
+	// import path, and each stub is placed relative to its own source package.
+	// The packages are loaded once and shared by every interface.
+	var selections []packageSelection
+	var err error
+	if selectorMode {
+		selections, err = loadSelectors(fs.Args())
+	} else {
+		input := fs.Arg(0)
+		var pkgs []*packages.Package
+		if info, statErr := os.Stat(input); statErr == nil && info.IsDir() {
+			pkgs, err = LoadPackages(input, ".")
+		} else {
+			layout.PerPackage = true
+			pkgs, err = LoadPackages("", input)
+		}
+		if err == nil {
+			selections, err = selectByName(pkgs, fs.Args()[1:], allFlag)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error finding interface: %v\n", err)
+		return 1
 	}
 
-	// Ensure the output directory exists
-	// This is handled by main_test.go for tests. For direct CLI usage, it's needed.
-	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
-		fmt.Fprintf(stderr, "Error creating output directory for %s: %v\n", outputFile, err)
+	files, err := planStubFiles(selections, layout)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error finding interface: %v\n", err)
 		return 1
 	}
 
+	return emitStubFiles(stdout, stderr, files, invocation, output)
+}
 
+// printUsage prints the ways of running the program to w.
+func printUsage(w io.Writer, program string) {
+	fmt.Fprintf(w,
+		"Usage: %s [-check|-dry-run] [-stub-dir <dir>] [-o <output.go>] [-combine] <input_directory|pattern> <interface>...\n"+
+			"       %s -all [-check|-dry-run] [-stub-dir <dir>] [-o <output.go>] [-combine] <input_directory|pattern>\n"+
+			"       %s [-check|-dry-run] [-stub-dir <dir>] [-o <output.go>] [-combine] <importpath.Interface>...\n"+
+			"       %s [-check|-dry-run] [-config <file>]\n",
+		program,
+		program,
+		program,
+		program)
+}
 
-	var opts = &options.StubOptions{WithLocking: true}
+// runConfig generates the stubs listed in the config file at configPath.
+func runConfig(stdout, stderr io.Writer, configPath, invocation string, output outputOptions) int {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error reading config: %v\n", err)
+		return 1
+	}
 
-	interfaceData, err := FindInterface(inputDir,
-		interfaceName,
-		actualStubDir)
+	files, err := planConfig(cfg, filepath.Dir(configPath))
 	if err != nil {
 		fmt.Fprintf(stderr, "Error finding interface: %v\n", err)
 		return 1
 	}
+	return emitStubFiles(stdout, stderr, files, invocation, output)
+}
 
-	stubCode, err := GenerateStubCode(interfaceData, opts)
-	if err != nil {
-		fmt.Fprintf(stderr, "Error generating stub: %v\n", err)
+// outputOptions controls what is done with the generated stub files.
+type outputOptions struct {
+	Check  bool // Report stale or missing stubs instead of writing them
+	DryRun bool // Report the files that would be created or changed instead of writing them
+	Stdout bool // Write the code of the single stub file to stdout
+	Force  bool // Overwrite existing files that were not generated by toe
+}
+
+// generatedFile is the formatted content of a planned stub file.
+type generatedFile struct {
+	Path    string
+	Content []byte
+}
+
+// emitStubFiles generates each planned stub file and then writes, prints or checks them.
+// Only writing them touches the filesystem. The invocation is recorded in each file's
+// header.
+func emitStubFiles(stdout, stderr io.Writer, files []stubFile, invocation string, output outputOptions) int {
+	if output.Stdout && len(files) > 1 {
+		fmt.Fprintf(stderr, "Error: -o - can only write a single stub file; use -combine to write several interfaces from one package\n")
 		return 1
 	}
 
-	// Format the generated code
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, outputFile, []byte(stubCode), parser.ParseComments)
+	generated, err := generateStubFiles(files, invocation)
 	if err != nil {
-		fmt.Fprintf(stderr,
-			"Error parsing generated code for formatting (%s): %v\n",
-			outputFile,
-			err)
+		fmt.Fprintf(stderr, "%v\n", err)
 		return 1
 	}
+	if !output.Check && !output.Stdout && !output.Force {
+		// Refuse before writing anything, so that no stub is left half-regenerated
+		if err := checkOverwrite(generated); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	switch {
+	case output.Check:
+		return checkStubFiles(stdout, stderr, generated)
+	case output.DryRun:
+		return reportStubFiles(stdout, stderr, generated)
+	case output.Stdout:
+		if _, err := stdout.Write(generated[0].Content); err != nil {
+			fmt.Fprintf(stderr, "Error writing to stdout: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	return writeStubFiles(stdout, stderr, generated)
+}
 
-	var formattedBuf strings.Builder
-	err = format.Node(&formattedBuf, fset, node)
-	if err != nil {
-		fmt.Fprintf(stderr, "Error formatting generated code (%s): %v\n", outputFile, err)
+// generateStubFiles generates and formats the code of each planned stub file in memory.
+func generateStubFiles(files []stubFile, invocation string) ([]generatedFile, error) {
+	var opts = &options.StubOptions{WithLocking: true}
+
+	generated := make([]generatedFile, 0, len(files))
+	for _, file := range files {
+		stubCode, err := GenerateStubsCode(file.Interfaces, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Error generating stub: %v", err)
+		}
+
+		header := GenerateHeader(file.Interfaces, invocation)
+		formatted, err := formatStubCode(file.Path, header+stubCode)
+		if err != nil {
+			return nil, err
+		}
+		generated = append(generated, generatedFile{Path: file.Path, Content: formatted})
+	}
+	return generated, nil
+}
+
+// checkOverwrite reports an error if any of the generated files would overwrite an
+// existing file that toe did not generate.
+func checkOverwrite(generated []generatedFile) error {
+	for _, file := range generated {
+		existing, err := os.ReadFile(file.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading existing stub: %v", err)
+		}
+		if !hasGeneratedMarker(existing) {
+			return fmt.Errorf("%s exists and was not generated by toe; use -force to overwrite it", file.Path)
+		}
+	}
+	return nil
+}
+
+// hasGeneratedMarker reports whether content carries toe's generated-code marker ahead
+// of its package clause.
+func hasGeneratedMarker(content []byte) bool {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == generatedMarker {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// toeInvocation reconstructs the command line recorded in the header of each stub from
+// the parsed flags. Flags that only choose what is done with the output are left out,
+// so that -check regenerates identical headers.
+func toeInvocation(fs *flag.FlagSet) string {
+	parts := []string{"toe"}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "check", "dry-run", "force":
+			return
+		}
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+			if f.Value.String() == "true" {
+				parts = append(parts, "-"+f.Name)
+			} else {
+				parts = append(parts, "-"+f.Name+"="+f.Value.String())
+			}
+			return
+		}
+		parts = append(parts, "-"+f.Name, shellQuote(f.Value.String()))
+	})
+	for _, arg := range fs.Args() {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote quotes arg for a POSIX shell if it contains anything but safe characters.
+func shellQuote(arg string) string {
+	safe := arg != ""
+	for _, r := range arg {
+		if !strings.ContainsRune("-_./:=@%+,", r) && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// writeStubFiles writes each generated stub file, creating its directory if needed.
+func writeStubFiles(stdout, stderr io.Writer, generated []generatedFile) int {
+	for _, file := range generated {
+		stubFile := file.Path
+
+		// Ensure the output directory exists
+		if err := os.MkdirAll(filepath.Dir(stubFile), 0755); err != nil {
+			fmt.Fprintf(stderr, "Error creating output directory for %s: %v\n", stubFile, err)
+			return 1
+		}
+
+		err := os.WriteFile(stubFile, file.Content, 0644)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error writing output file: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "Stub generated in %s\n", stubFile)
+	}
+
+	return 0
+}
+
+// reportStubFiles prints whether each generated stub file would be created, updated
+// or left unchanged, without writing anything.
+func reportStubFiles(stdout, stderr io.Writer, generated []generatedFile) int {
+	for _, file := range generated {
+		existing, err := os.ReadFile(file.Path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Fprintf(stdout, "Would create %s\n", file.Path)
+		case err != nil:
+			fmt.Fprintf(stderr, "Error reading existing stub: %v\n", err)
+			return 1
+		case sameStub(existing, file.Content):
+			fmt.Fprintf(stdout, "Unchanged %s\n", file.Path)
+		default:
+			fmt.Fprintf(stdout, "Would update %s\n", file.Path)
+		}
+	}
+	return 0
+}
+
+// checkStubFiles compares each generated stub file with the one on disk, printing a
+// unified diff for every stub that is missing or out of date. It never writes files.
+func checkStubFiles(stdout, stderr io.Writer, generated []generatedFile) int {
+	stale := 0
+	for _, file := range generated {
+		existing, err := os.ReadFile(file.Path)
+		oldName := file.Path
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Fprintf(stderr, "Stub %s is missing\n", file.Path)
+			oldName = "/dev/null"
+		case err != nil:
+			fmt.Fprintf(stderr, "Error reading existing stub: %v\n", err)
+			return 1
+		case sameStub(existing, file.Content):
+			continue
+		default:
+			fmt.Fprintf(stderr, "Stub %s is out of date\n", file.Path)
+		}
+		stale++
+		fmt.Fprint(stdout, unifiedDiff(oldName, file.Path+" (regenerated)", existing, file.Content))
+	}
+
+	if stale > 0 {
+		fmt.Fprintf(stderr, "%d of %d stub files need regenerating; run toe without -check to update them\n",
+			stale,
+			len(generated))
 		return 1
 	}
-	stubCode = formattedBuf.String()
+	return 0
+}
+
+// sameStub reports whether the stub file existing is what toe generated as content. The
+// invocation recorded in the header only says how a stub was generated, which may
+// differ between equivalent command lines, so it is not compared.
+func sameStub(existing, content []byte) bool {
+	return bytes.Equal(withoutInvocation(existing), withoutInvocation(content))
+}
+
+// withoutInvocation removes the invocation line from the header of a stub file.
+func withoutInvocation(content []byte) []byte {
+	var kept [][]byte
+	inHeader := true
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("package ")) {
+			inHeader = false
+		}
+		if !inHeader || !bytes.HasPrefix(line, []byte("// Invocation: ")) {
+			kept = append(kept, line)
+		}
+	}
+	return bytes.Join(kept, nil)
+}
+
+// loadSelectors loads the packages named by "importpath.Interface" selectors and
+// selects the interfaces from them.
+func loadSelectors(selectors []string) ([]packageSelection, error) {
+	var importPaths []string
+	seen := make(map[string]bool)
+	for _, selector := range selectors {
+		importPath, _, ok := parseInterfaceSelector(selector)
+		if !ok {
+			return nil, fmt.Errorf("%s is not of the form importpath.Interface", selector)
+		}
+		if !seen[importPath] {
+			seen[importPath] = true
+			importPaths = append(importPaths, importPath)
+		}
+	}
 
-	err = os.WriteFile(outputFile, []byte(stubCode), 0644)
+	pkgs, err := LoadPackages("", importPaths...)
 	if err != nil {
-		fmt.Fprintf(stderr, "Error writing output file: %v\n", err)
-		return 1
+		return nil, err
 	}
-	fmt.Fprintf(stdout, "Stub generated in %s\n", outputFile)
+	return selectBySelector(pkgs, selectors)
+}
 
-	return 0
+// formatStubCode reparses generated code and formats it in canonical gofmt style,
+// which also settles the placement of comments attached to the AST.
+func formatStubCode(outputFile string, stubCode string) ([]byte, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, outputFile, []byte(stubCode), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing generated code for formatting (%s): %v", outputFile, err)
+	}
+
+	var formattedBuf strings.Builder
+	err = format.Node(&formattedBuf, fset, node)
+	if err != nil {
+		return nil, fmt.Errorf("Error formatting generated code (%s): %v", outputFile, err)
+	}
+	return []byte(formattedBuf.String()), nil
 }
 
 func main() {
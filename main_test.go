@@ -17,6 +17,8 @@ type TestCase struct {
 	InterfaceName string
 	GoldenFile    string
 	Flags         []string // Additional flags for toe command
+	Implements    string   // Optional interface the stub must satisfy, e.g. "variadic.Logger"
+	InSource      bool     // Generate into the input package itself instead of a temp stub dir
 }
 
 func TestGenerateStub(t *testing.T) {
@@ -28,6 +30,7 @@ func TestGenerateStub(t *testing.T) {
 			InterfaceName: "MyInterface",
 			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_myinterface.go"), // Default output path
 			Flags:         []string{},                                                             // No flags for default
+			Implements:    "simple.MyInterface",
 		},
 		{
 			Name:          "generic_default_output",
@@ -35,6 +38,7 @@ func TestGenerateStub(t *testing.T) {
 			InterfaceName: "GenericInterface",
 			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_genericinterface.go"), // Default output path
 			Flags:         []string{},                                                              // No flags for default
+			Implements:    "generic.GenericInterface[int]",
 		},
 
 		{
@@ -44,6 +48,150 @@ func TestGenerateStub(t *testing.T) {
 			GoldenFile:    filepath.Join("testdata", "golden", "customstubs", "stub_myinterface.go"), // Custom stub dir output path
 			Flags:         []string{"--stub-dir", "customstubs"},
 		},
+		{
+			Name:          "variadic_default_output",
+			InputFile:     filepath.Join("testdata", "input", "variadic"),
+			InterfaceName: "Logger",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_logger.go"),
+			Flags:         []string{},
+			Implements:    "variadic.Logger",
+		},
+		{
+			Name:          "unnamed_params_writer",
+			InputFile:     filepath.Join("testdata", "input", "unnamed"),
+			InterfaceName: "Writer",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_writer.go"),
+			Flags:         []string{},
+			Implements:    "unnamed.Writer",
+		},
+		{
+			Name:          "blank_params_handler",
+			InputFile:     filepath.Join("testdata", "input", "unnamed"),
+			InterfaceName: "Handler",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_handler.go"),
+			Flags:         []string{},
+			Implements:    "unnamed.Handler",
+		},
+		{
+			Name:          "inline_interface_struct_func_types",
+			InputFile:     filepath.Join("testdata", "input", "inline"),
+			InterfaceName: "Inline",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_inline.go"),
+			Flags:         []string{},
+			Implements:    "inline.Inline",
+		},
+		{
+			Name:          "instantiated_generic_types",
+			InputFile:     filepath.Join("testdata", "input", "generictypes"),
+			InterfaceName: "Cache",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_cache.go"),
+			Flags:         []string{},
+			Implements:    "generictypes.Cache[string, int]",
+		},
+		{
+			Name:          "source_package_types_qualified",
+			InputFile:     filepath.Join("testdata", "input", "samepkg"),
+			InterfaceName: "Service",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_service.go"),
+			Flags:         []string{"--stub-dir", "stubs"},
+			Implements:    "samepkg.Service",
+		},
+		{
+			Name:          "source_package_types_unqualified",
+			InputFile:     filepath.Join("testdata", "input", "samepkg"),
+			InterfaceName: "Service",
+			GoldenFile:    filepath.Join("testdata", "golden", "samepkg", "stub_service.go"),
+			Flags:         []string{},
+			Implements:    "Service",
+			InSource:      true,
+		},
+		{
+			Name:          "import_alias_conflicts",
+			InputFile:     filepath.Join("testdata", "input", "aliases"),
+			InterfaceName: "Cluster",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_cluster.go"),
+			Flags:         []string{},
+			Implements:    "aliases.Cluster",
+		},
+		{
+			Name:          "type_parameter_constraints",
+			InputFile:     filepath.Join("testdata", "input", "constraints"),
+			InterfaceName: "Aggregator",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_aggregator.go"),
+			Flags:         []string{},
+			Implements:    "constraints.Aggregator[int, string, []int, constraints.Name, int, float64]",
+		},
+		{
+			Name:          "embedded_generic_interfaces",
+			InputFile:     filepath.Join("testdata", "input", "embedded"),
+			InterfaceName: "Store",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_store.go"),
+			Flags:         []string{},
+			Implements:    "embedded.Store[int]",
+		},
+		{
+			Name:          "embedded_external_interfaces",
+			InputFile:     filepath.Join("testdata", "input", "embedded"),
+			InterfaceName: "ReadStore",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_readstore.go"),
+			Flags:         []string{},
+			Implements:    "embedded.ReadStore",
+		},
+		{
+			Name:          "methods_named_like_generated_fields",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Clashing",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_clashing.go"),
+			Flags:         []string{},
+			Implements:    "collisions.Clashing",
+		},
+		{
+			Name:          "params_named_like_stub_internals",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Shadowing",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_shadowing.go"),
+			Flags:         []string{},
+			Implements:    "collisions.Shadowing",
+		},
+		{
+			Name:          "params_named_like_type_params",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Generic",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_generic.go"),
+			Flags:         []string{},
+			Implements:    "collisions.Generic[int, string]",
+		},
+		{
+			Name:          "results_named_by_type",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Results",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_results.go"),
+			Flags:         []string{},
+			Implements:    "collisions.Results",
+		},
+		{
+			Name:          "results_named_by_index",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Results",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_results_index.go"),
+			Flags:         []string{"-result-names", "index"},
+			Implements:    "collisions.Results",
+		},
+		{
+			Name:          "results_named_where_named",
+			InputFile:     filepath.Join("testdata", "input", "collisions"),
+			InterfaceName: "Results",
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_results_named.go"),
+			Flags:         []string{"-result-names", "named"},
+			Implements:    "collisions.Results",
+		},
+		{
+			Name:          "all_interfaces_combined",
+			InputFile:     filepath.Join("testdata", "input", "embedded"),
+			InterfaceName: "", // -all selects every exported interface
+			GoldenFile:    filepath.Join("testdata", "golden", "stubs", "stub_embedded.go"),
+			Flags:         []string{"-all", "-combine"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -62,21 +210,35 @@ func TestGenerateStub(t *testing.T) {
 			}
 
 			outputFilename := fmt.Sprintf("stub_%s.go", strings.ToLower(tc.InterfaceName))
+			if tc.InterfaceName == "" {
+				outputFilename = fmt.Sprintf("stub_%s.go", filepath.Base(tc.InputFile))
+			}
 
 			// Create a temporary output file path. 
 			testTempDir := t.TempDir()
 			finalOutputDir := filepath.Join(testTempDir, generatedStubDir) // Use generatedStubDir here
+			if tc.InSource {
+				// The stub joins the input package, so it is written there and removed afterwards
+				generatedStubDir = filepath.Base(tc.InputFile)
+				finalOutputDir = tc.InputFile
+			}
 			if err := os.MkdirAll(finalOutputDir, 0755); err != nil {
 				t.Fatalf("Failed to create temp output directory: %v", err)
 			}
 			outputFilePath := filepath.Join(finalOutputDir, outputFilename)
+			if tc.InSource {
+				t.Cleanup(func() { os.Remove(outputFilePath) })
+			}
 
 			// Prepare arguments for the run function
 			args := []string{"toe"}
 			args = append(args, tc.Flags...)
 			// The run function now automatically determines the output path and filename.
 			// However, we pass -o to force it to write to our temporary file path for comparison.
-			args = append(args, "-o", outputFilePath, tc.InputFile, tc.InterfaceName)
+			args = append(args, "-o", outputFilePath, tc.InputFile)
+			if tc.InterfaceName != "" {
+				args = append(args, tc.InterfaceName)
+			}
 
 			// Capture stdout/stderr
 			var outBuffer, errBuffer bytes.Buffer
@@ -102,7 +264,7 @@ func TestGenerateStub(t *testing.T) {
 			}
 
 			// Compare generated to golden
-			if !bytes.Equal(generated, golden) {
+			if !sameStub(generated, golden) {
 				diff := generateDiff(generated, golden)
 				t.Errorf("Generated output for %s does not match golden file.\nDiff:\n%s", tc.Name, diff)
 			}
@@ -111,6 +273,30 @@ func TestGenerateStub(t *testing.T) {
 			cmd := exec.Command("go", "build")
 			cmd.Dir = "." // Run build from the project root (assuming tests run from project root)
 			cmd.Args = append(cmd.Args, outputFilePath) // Build the generated file
+			if tc.Implements != "" {
+				importPath := "github.com/phildrip/toe/" + filepath.ToSlash(tc.InputFile)
+				if tc.InSource {
+					importPath = ""
+				}
+				typeArgs := ""
+				if i := strings.Index(tc.Implements, "["); i != -1 {
+					typeArgs = tc.Implements[i:]
+				}
+				checkPath := writeImplementsCheck(t,
+					finalOutputDir,
+					generatedStubDir,
+					importPath,
+					tc.Implements,
+					"Stub"+tc.InterfaceName+typeArgs)
+				if tc.InSource {
+					t.Cleanup(func() { os.Remove(checkPath) })
+				}
+				cmd.Args = append(cmd.Args, checkPath)
+			}
+			if tc.InSource {
+				// A stub inside the input package can only be built together with it
+				cmd.Args = []string{"go", "build", "./" + filepath.ToSlash(tc.InputFile)}
+			}
 			
 			var buildStderr bytes.Buffer
 			cmd.Stderr = &buildStderr
@@ -121,9 +307,712 @@ func TestGenerateStub(t *testing.T) {
 	}
 }
 
-// generateDiff is a helper to produce a diff string (simplified for demonstration)
+func TestGenerateStubPerInterface(t *testing.T) {
+	inputDir := filepath.Join("testdata", "input", "unnamed")
+	goldenDir := filepath.Join("testdata", "golden", "stubs")
+
+	testCases := []struct {
+		Name string
+		Args []string
+	}{
+		{Name: "named_interfaces", Args: []string{inputDir, "Writer", "Handler"}},
+		{Name: "all_interfaces", Args: []string{"-all", inputDir}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stubDir := filepath.Join(t.TempDir(), "stubs")
+			args := append([]string{"toe", "-stub-dir", stubDir}, tc.Args...)
+
+			var outBuffer, errBuffer bytes.Buffer
+			if exitCode := run(&outBuffer, &errBuffer, args); exitCode != 0 {
+				t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+			}
+
+			// Each interface gets its own file, identical to generating it on its own
+			for _, filename := range []string{"stub_handler.go", "stub_writer.go"} {
+				generated, err := os.ReadFile(filepath.Join(stubDir, filename))
+				if err != nil {
+					t.Fatalf("Failed to read generated file %s: %v", filename, err)
+				}
+				golden, err := os.ReadFile(filepath.Join(goldenDir, filename))
+				if err != nil {
+					t.Fatalf("Failed to read golden file %s: %v", filename, err)
+				}
+				if !sameStub(generated, golden) {
+					t.Errorf("Generated %s does not match golden file.\nDiff:\n%s", filename, generateDiff(generated, golden))
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateStubsForPattern(t *testing.T) {
+	// Patterns are resolved against the working directory, so the packages live in a
+	// throwaway module and stubs are generated beside each of them
+	moduleFiles := map[string]string{
+		"go.mod":    "module example.com/patterns\n\ngo 1.24\n",
+		"a/a.go":    "package a\n\ntype Reader interface {\n\tRead() string\n}\n",
+		"b/c/c.go":  "package c\n\ntype Reader interface {\n\tRead() string\n}\n",
+		"b/c/cw.go": "package c\n\ntype Writer interface {\n\tWrite(s string)\n}\n",
+	}
+
+	testCases := []struct {
+		Name     string
+		Args     []string
+		Expected []string // Generated files, relative to the module root
+		Absent   []string
+	}{
+		{
+			Name:     "recursive_pattern",
+			Args:     []string{"./...", "Reader"},
+			Expected: []string{"a/stubs/stub_reader.go", "b/c/stubs/stub_reader.go"},
+			Absent:   []string{"b/c/stubs/stub_writer.go"},
+		},
+		{
+			Name:     "import_path_pattern",
+			Args:     []string{"-all", "example.com/patterns/b/..."},
+			Expected: []string{"b/c/stubs/stub_reader.go", "b/c/stubs/stub_writer.go"},
+			Absent:   []string{"a/stubs/stub_reader.go"},
+		},
+		{
+			Name:     "combined_per_package",
+			Args:     []string{"-all", "-combine", "./..."},
+			Expected: []string{"a/stubs/stub_a.go", "b/c/stubs/stub_c.go"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			moduleDir := writeModule(t, moduleFiles)
+			t.Chdir(moduleDir)
+
+			var outBuffer, errBuffer bytes.Buffer
+			args := append([]string{"toe"}, tc.Args...)
+			if exitCode := run(&outBuffer, &errBuffer, args); exitCode != 0 {
+				t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+			}
+
+			for _, name := range tc.Expected {
+				generated, err := os.ReadFile(filepath.Join(moduleDir, name))
+				if err != nil {
+					t.Fatalf("Expected stub %s was not generated: %v", name, err)
+				}
+				if !bytes.Contains(generated, []byte("\n\npackage stubs\n")) {
+					t.Errorf("Stub %s is not in package stubs:\n%s", name, generated)
+				}
+			}
+			for _, name := range tc.Absent {
+				if _, err := os.Stat(filepath.Join(moduleDir, name)); err == nil {
+					t.Errorf("Unexpected stub %s was generated", name)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateStubForSelector(t *testing.T) {
+	goldenDir := filepath.Join("testdata", "golden", "stubs")
+
+	testCases := []struct {
+		Name       string
+		Selector   string
+		GoldenFile string
+		Implements string // Interface the stub must satisfy, qualified by its package name
+	}{
+		{Name: "standard_library", Selector: "io.ReadCloser", GoldenFile: "stub_readcloser.go", Implements: "io.ReadCloser"},
+		{Name: "nested_import_path", Selector: "net/http.RoundTripper", GoldenFile: "stub_roundtripper.go", Implements: "http.RoundTripper"},
+		{Name: "deeply_nested_import_path", Selector: "database/sql/driver.Conn", GoldenFile: "stub_conn.go", Implements: "driver.Conn"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stubDir := filepath.Join(t.TempDir(), "stubs")
+			outputFilePath := filepath.Join(stubDir, tc.GoldenFile)
+
+			var outBuffer, errBuffer bytes.Buffer
+			args := []string{"toe", "-o", outputFilePath, tc.Selector}
+			if exitCode := run(&outBuffer, &errBuffer, args); exitCode != 0 {
+				t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+			}
+
+			generated, err := os.ReadFile(outputFilePath)
+			if err != nil {
+				t.Fatalf("Failed to read generated file %s: %v", outputFilePath, err)
+			}
+			golden, err := os.ReadFile(filepath.Join(goldenDir, tc.GoldenFile))
+			if err != nil {
+				t.Fatalf("Failed to read golden file %s: %v", tc.GoldenFile, err)
+			}
+			if !sameStub(generated, golden) {
+				t.Errorf("Generated output for %s does not match golden file.\nDiff:\n%s", tc.Selector, generateDiff(generated, golden))
+			}
+
+			// The stub must satisfy the interface it was generated from
+			importPath, interfaceName, _ := parseInterfaceSelector(tc.Selector)
+			buildWithImplementsCheck(t, outputFilePath, importPath, tc.Implements, "Stub"+interfaceName)
+		})
+	}
+}
+
+// TestConstraintInterfaces checks that interfaces usable only as type constraints, which
+// no stub could satisfy, are rejected however they are selected.
+func TestConstraintInterfaces(t *testing.T) {
+	for _, args := range [][]string{
+		{"cmp.Ordered"},
+		{filepath.Join("testdata", "input", "constraints"), "Number"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var outBuffer, errBuffer bytes.Buffer
+			if exitCode := run(&outBuffer, &errBuffer, append([]string{"toe", "-o", "-"}, args...)); exitCode == 0 {
+				t.Fatalf("toe exited with status 0, generating:\n%s", outBuffer.String())
+			}
+			if !strings.Contains(errBuffer.String(), "is a type constraint and cannot be stubbed") {
+				t.Errorf("Expected an error rejecting the constraint, got: %s", errBuffer.String())
+			}
+		})
+	}
+}
+
+func TestGenerateStubsFromConfig(t *testing.T) {
+	// The config lives at the root of a throwaway module and is found from a
+	// subdirectory, with its paths resolved against the module root
+	moduleFiles := map[string]string{
+		"go.mod":    "module example.com/configured\n\ngo 1.24\n",
+		"a/a.go":    "package a\n\ntype Reader interface {\n\tRead() string\n}\n",
+		"b/c/c.go":  "package c\n\ntype Reader interface {\n\tRead() string\n}\n",
+		"b/c/cw.go": "package c\n\ntype Writer interface {\n\tWrite(s string)\n}\n",
+		configFileName: `{
+  "stubs": [
+    {"package": "./b/...", "all": true, "combine": true},
+    {
+      "package": "a",
+      "stub_dir": "fakes",
+      "package_name": "fakes",
+      "stub_name": "Fake{{.Name}}",
+      "interfaces": [{"name": "Reader", "output": "fakes/reader.go"}]
+    },
+    {"interfaces": [{"name": "io.Closer", "stub_name": "Closer{{.Name}}Stub"}]}
+  ]
+}
+`,
+	}
+
+	moduleDir := writeModule(t, moduleFiles)
+	t.Chdir(filepath.Join(moduleDir, "b"))
+
+	var outBuffer, errBuffer bytes.Buffer
+	if exitCode := run(&outBuffer, &errBuffer, []string{"toe"}); exitCode != 0 {
+		t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+	}
+
+	expected := map[string][]string{ // Generated file, relative to the module root, and expected content
+		"b/c/stubs/stub_c.go":  {"package stubs\n", "type StubReader struct", "type StubWriter struct"},
+		"fakes/reader.go":      {"package fakes\n", "type FakeReader struct", "func NewFakeReader("},
+		"stubs/stub_closer.go": {"package stubs\n", "type CloserCloserStub struct"},
+	}
+	for name, contents := range expected {
+		generated, err := os.ReadFile(filepath.Join(moduleDir, name))
+		if err != nil {
+			t.Fatalf("Expected stub %s was not generated: %v", name, err)
+		}
+		for _, content := range contents {
+			if !bytes.Contains(generated, []byte(content)) {
+				t.Errorf("Stub %s does not contain %q:\n%s", name, content, generated)
+			}
+		}
+	}
+}
+
+// TestNoArgumentsWithoutConfig checks that running toe without arguments where there is
+// no config file prints the usage.
+func TestNoArgumentsWithoutConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var outBuffer, errBuffer bytes.Buffer
+	if exitCode := run(&outBuffer, &errBuffer, []string{"toe"}); exitCode != 1 {
+		t.Fatalf("toe exited with status %d, expected 1", exitCode)
+	}
+	for _, want := range []string{"no .toe.json found", "Usage: toe "} {
+		if !strings.Contains(errBuffer.String(), want) {
+			t.Errorf("Expected stderr containing %q, got:\n%s", want, errBuffer.String())
+		}
+	}
+}
+
+func TestCheckStubs(t *testing.T) {
+	inputDir := filepath.Join("testdata", "input", "simple")
+
+	testCases := []struct {
+		Name         string
+		Generate     bool                             // Generate the stub before checking it
+		Edit         func(content []byte) []byte      // Optional change to the generated stub
+		CheckArgs    func(outputFile string) []string // Optional other invocation to check with
+		ExitCode     int
+		ExpectedDiff string
+	}{
+		{Name: "up_to_date", Generate: true},
+		{
+			// The invocation recorded in the header differs, but the stub is the same
+			Name:     "equivalent_invocation",
+			Generate: true,
+			CheckArgs: func(outputFile string) []string {
+				absOutput, err := filepath.Abs(outputFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return []string{"-o", absOutput, "./" + filepath.ToSlash(inputDir), "MyInterface"}
+			},
+		},
+		{
+			Name:     "stale",
+			Generate: true,
+			Edit: func(content []byte) []byte {
+				return bytes.Replace(content, []byte("\tVal string\n"), []byte("\tValue string\n"), 1)
+			},
+			ExitCode:     1,
+			ExpectedDiff: "-\tValue string\n+\tVal string\n",
+		},
+		{Name: "missing", ExitCode: 1, ExpectedDiff: "--- /dev/null\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			outputFilePath := filepath.Join(t.TempDir(), "stubs", "stub_myinterface.go")
+			args := []string{"-o", outputFilePath, inputDir, "MyInterface"}
+
+			var existing []byte
+			if tc.Generate {
+				var outBuffer, errBuffer bytes.Buffer
+				if exitCode := run(&outBuffer, &errBuffer, append([]string{"toe"}, args...)); exitCode != 0 {
+					t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+				}
+				var err error
+				if existing, err = os.ReadFile(outputFilePath); err != nil {
+					t.Fatalf("Failed to read generated file: %v", err)
+				}
+				if tc.Edit != nil {
+					existing = tc.Edit(existing)
+					if err := os.WriteFile(outputFilePath, existing, 0644); err != nil {
+						t.Fatalf("Failed to write existing stub: %v", err)
+					}
+				}
+			}
+
+			var outBuffer, errBuffer bytes.Buffer
+			if tc.CheckArgs != nil {
+				args = tc.CheckArgs(outputFilePath)
+			}
+			checkArgs := append([]string{"toe", "-check"}, args...)
+			if exitCode := run(&outBuffer, &errBuffer, checkArgs); exitCode != tc.ExitCode {
+				t.Fatalf("toe exited with status %d, expected %d\nStderr: %s", exitCode, tc.ExitCode, errBuffer.String())
+			}
+			if !strings.Contains(outBuffer.String(), tc.ExpectedDiff) || (tc.ExpectedDiff == "") != (outBuffer.Len() == 0) {
+				t.Errorf("Expected diff containing %q, got:\n%s", tc.ExpectedDiff, outBuffer.String())
+			}
+
+			// The filesystem is left untouched
+			if existing == nil {
+				if _, err := os.Stat(filepath.Dir(outputFilePath)); err == nil {
+					t.Errorf("-check created %s", filepath.Dir(outputFilePath))
+				}
+			} else if content, _ := os.ReadFile(outputFilePath); !bytes.Equal(content, existing) {
+				t.Errorf("-check modified %s", outputFilePath)
+			}
+		})
+	}
+}
+
+func TestStdoutAndDryRun(t *testing.T) {
+	inputDir, err := filepath.Abs(filepath.Join("testdata", "input", "unnamed"))
+	if err != nil {
+		t.Fatalf("Failed to resolve input directory: %v", err)
+	}
+	golden, err := os.ReadFile(filepath.Join("testdata", "golden", "stubs", "stub_writer.go"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	staleStub := []byte(generatedMarker + "\n\npackage stubs\n")
+
+	testCases := []struct {
+		Name     string
+		Args     []string
+		Generate bool              // Generate the stubs with the same arguments, less -dry-run, first
+		Existing map[string][]byte // Stubs then written to disk, relative to the working directory
+		ExitCode int
+		Expected string // Exact stdout
+	}{
+		{
+			Name:     "dry_run",
+			Args:     []string{"-dry-run", inputDir, "Writer", "Handler"},
+			Expected: "Would create stubs/stub_writer.go\nWould create stubs/stub_handler.go\n",
+		},
+		{
+			Name:     "dry_run_existing",
+			Args:     []string{"-dry-run", inputDir, "Writer", "Handler"},
+			Generate: true,
+			Existing: map[string][]byte{"stubs/stub_handler.go": staleStub},
+			Expected: "Unchanged stubs/stub_writer.go\nWould update stubs/stub_handler.go\n",
+		},
+		{
+			Name:     "dry_run_refuses_overwrite",
+			Args:     []string{"-dry-run", inputDir, "Writer"},
+			Existing: map[string][]byte{"stubs/stub_writer.go": []byte("package stubs\n")},
+			ExitCode: 1,
+		},
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		var outBuffer, errBuffer bytes.Buffer
+		if exitCode := run(&outBuffer, &errBuffer, []string{"toe", "-o", "-", inputDir, "Writer"}); exitCode != 0 {
+			t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+		}
+		if !sameStub(outBuffer.Bytes(), golden) {
+			t.Errorf("Unexpected stdout:\n%s", generateDiff(outBuffer.Bytes(), golden))
+		}
+		if entries, _ := os.ReadDir("."); len(entries) > 0 {
+			t.Errorf("Expected no files to be created, found %s", entries[0].Name())
+		}
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			workDir := t.TempDir()
+			t.Chdir(workDir)
+			if tc.Generate {
+				var outBuffer, errBuffer bytes.Buffer
+				args := append([]string{"toe"}, tc.Args[1:]...)
+				if exitCode := run(&outBuffer, &errBuffer, args); exitCode != 0 {
+					t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+				}
+			}
+			for name, content := range tc.Existing {
+				if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+					t.Fatalf("Failed to create %s: %v", filepath.Dir(name), err)
+				}
+				if err := os.WriteFile(name, content, 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			var outBuffer, errBuffer bytes.Buffer
+			args := append([]string{"toe"}, tc.Args...)
+			if exitCode := run(&outBuffer, &errBuffer, args); exitCode != tc.ExitCode {
+				t.Fatalf("toe exited with status %d, expected %d\nStderr: %s", exitCode, tc.ExitCode, errBuffer.String())
+			}
+			if outBuffer.String() != tc.Expected {
+				t.Errorf("Unexpected stdout:\n%s", unifiedDiff("stdout", "expected", outBuffer.Bytes(), []byte(tc.Expected)))
+			}
+
+			// Nothing is created or changed on disk
+			entries, err := os.ReadDir(workDir)
+			if err != nil {
+				t.Fatalf("Failed to read working directory: %v", err)
+			}
+			if !tc.Generate && len(tc.Existing) == 0 && len(entries) > 0 {
+				t.Errorf("Expected no files to be created, found %s", entries[0].Name())
+			}
+			for name, content := range tc.Existing {
+				if current, _ := os.ReadFile(name); !bytes.Equal(current, content) {
+					t.Errorf("%s was modified", name)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratedHeader(t *testing.T) {
+	inputDir := filepath.Join("testdata", "input", "simple")
+	foreign := []byte("package stubs\n\n// Hand-written code\n")
+
+	testCases := []struct {
+		Name     string
+		Flags    []string
+		Existing []byte // Content of the output file before running; nil if it is missing
+		ExitCode int
+	}{
+		{Name: "new_file"},
+		{Name: "regenerated_file", Existing: []byte(generatedMarker + "\n\npackage stubs\n")},
+		{Name: "refuses_foreign_file", Existing: foreign, ExitCode: 1},
+		{Name: "forced_overwrite", Flags: []string{"-force"}, Existing: foreign},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			outputFilePath := filepath.Join(t.TempDir(), "stubs", "stub_myinterface.go")
+			if tc.Existing != nil {
+				if err := os.MkdirAll(filepath.Dir(outputFilePath), 0755); err != nil {
+					t.Fatalf("Failed to create output directory: %v", err)
+				}
+				if err := os.WriteFile(outputFilePath, tc.Existing, 0644); err != nil {
+					t.Fatalf("Failed to write existing file: %v", err)
+				}
+			}
+
+			var outBuffer, errBuffer bytes.Buffer
+			args := append([]string{"toe"}, tc.Flags...)
+			args = append(args, "-o", outputFilePath, inputDir, "MyInterface")
+			if exitCode := run(&outBuffer, &errBuffer, args); exitCode != tc.ExitCode {
+				t.Fatalf("toe exited with status %d, expected %d\nStderr: %s", exitCode, tc.ExitCode, errBuffer.String())
+			}
+
+			generated, err := os.ReadFile(outputFilePath)
+			if err != nil {
+				t.Fatalf("Failed to read output file: %v", err)
+			}
+			if tc.ExitCode != 0 {
+				if !bytes.Equal(generated, tc.Existing) {
+					t.Errorf("Existing file was overwritten:\n%s", generated)
+				}
+				if !strings.Contains(errBuffer.String(), "use -force to overwrite") {
+					t.Errorf("Expected an error suggesting -force, got: %s", errBuffer.String())
+				}
+				return
+			}
+
+			// -force chooses what is done with the output, so it is not recorded
+			expected := fmt.Sprintf("%s\n// Source: github.com/phildrip/toe/testdata/input/simple.MyInterface\n"+
+				"// Invocation: toe -o %s %s MyInterface\n\npackage stubs\n",
+				generatedMarker,
+				outputFilePath,
+				inputDir)
+			if !bytes.HasPrefix(generated, []byte(expected)) {
+				t.Errorf("Expected header:\n%s\ngot:\n%s", expected, generated)
+			}
+		})
+	}
+}
+
+func TestNamingTemplates(t *testing.T) {
+	inputDir := filepath.Join("testdata", "input", "simple")
+	// Names in the style of counterfeiter
+	counterfeiterFlags := []string{
+		"-stub-name", "Fake{{.Name}}",
+		"-constructor-name", "NewFake{{.Name}}",
+		"-func-name", "{{.Method}}Stub",
+		"-calls-name", "{{.Method}}ArgsForCall",
+		"-returns-name", "{{.Method}}ReturnsValues",
+		"-call-type-name", "{{.Stub}}{{.Method}}Args",
+		"-returns-type-name", "{{.Stub}}{{.Method}}Results",
+	}
+
+	testCases := []struct {
+		Name          string
+		Flags         []string
+		GoldenFile    string // Expected output; "" if generation must fail
+		ExpectedError string
+	}{
+		{
+			Name:       "counterfeiter_style",
+			Flags:      counterfeiterFlags,
+			GoldenFile: filepath.Join("testdata", "golden", "stubs", "fake_myinterface.go"),
+		},
+		{
+			Name:          "field_clashes_with_method",
+			Flags:         []string{"-func-name", "{{.Method}}"},
+			ExpectedError: "would be named Calculate, which clashes with method Calculate",
+		},
+		{
+			Name:          "fields_clash",
+			Flags:         []string{"-calls-name", "{{.Method}}Func"},
+			ExpectedError: "would be named CalculateFunc, which clashes with the func field of Calculate",
+		},
+		{
+			Name:          "types_clash",
+			Flags:         []string{"-call-type-name", "{{.Stub}}Call"},
+			ExpectedError: "declares StubMyInterfaceCall, which clashes with another declaration",
+		},
+		{
+			Name:          "invalid_identifier",
+			Flags:         []string{"-stub-name", "{{.Name}}-Stub"},
+			ExpectedError: `produced "MyInterface-Stub", which is not a valid Go identifier`,
+		},
+		{
+			Name:          "invalid_template",
+			Flags:         []string{"-func-name", "{{.Method"},
+			ExpectedError: "invalid func field name template",
+		},
+		{
+			Name:          "invalid_result_names",
+			Flags:         []string{"-result-names", "position"},
+			ExpectedError: `invalid result naming "position"; must be type, index or named`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stubDir := filepath.Join(t.TempDir(), "stubs")
+			outputFilePath := filepath.Join(stubDir, "stub_myinterface.go")
+
+			var outBuffer, errBuffer bytes.Buffer
+			args := append([]string{"toe"}, tc.Flags...)
+			args = append(args, "-o", outputFilePath, inputDir, "MyInterface")
+			exitCode := run(&outBuffer, &errBuffer, args)
+			if tc.GoldenFile == "" {
+				if exitCode == 0 || !strings.Contains(errBuffer.String(), tc.ExpectedError) {
+					t.Fatalf("Expected failure containing %q, got status %d\nStderr: %s",
+						tc.ExpectedError,
+						exitCode,
+						errBuffer.String())
+				}
+				return
+			}
+			if exitCode != 0 {
+				t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+			}
+
+			generated, err := os.ReadFile(outputFilePath)
+			if err != nil {
+				t.Fatalf("Failed to read generated file: %v", err)
+			}
+			golden, err := os.ReadFile(tc.GoldenFile)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if !sameStub(generated, golden) {
+				t.Errorf("Generated output does not match golden file.\nDiff:\n%s", generateDiff(generated, golden))
+			}
+
+			// The renamed stub must still satisfy the interface
+			buildWithImplementsCheck(t,
+				outputFilePath,
+				"github.com/phildrip/toe/"+filepath.ToSlash(inputDir),
+				"simple.MyInterface",
+				"FakeMyInterface")
+		})
+	}
+}
+
+// TestSequencedReturns runs tests of sequenced return values against a generated stub.
+func TestSequencedReturns(t *testing.T) {
+	runBehaviourTest(t, "sequence_test.go", []string{"github.com/phildrip/toe/testdata/input/simple.MyInterface"})
+}
+
+// TestMatchedReturns runs tests of argument-matched return values against generated
+// stubs.
+func TestMatchedReturns(t *testing.T) {
+	runBehaviourTest(t, "matcher_test.go", []string{
+		"github.com/phildrip/toe/testdata/input/simple.MyInterface",
+		"github.com/phildrip/toe/testdata/input/variadic.Logger",
+	})
+}
+
+// TestStrictStubs runs tests of strict stubs, which fail unconfigured calls, against
+// generated stubs.
+func TestStrictStubs(t *testing.T) {
+	runBehaviourTest(t, "strict_test.go", []string{
+		"github.com/phildrip/toe/testdata/input/simple.MyInterface",
+		"github.com/phildrip/toe/testdata/input/variadic.Logger",
+	})
+}
+
+// TestExpectedCalls runs tests of the calls expected of stubs created for a test against
+// a generated stub.
+func TestExpectedCalls(t *testing.T) {
+	runBehaviourTest(t, "expect_test.go", []string{"github.com/phildrip/toe/testdata/input/simple.MyInterface"})
+}
+
+// TestCallAccessorsRace runs a test reading a stub's calls while other goroutines call
+// it against a generated stub, under the race detector.
+func TestCallAccessorsRace(t *testing.T) {
+	runBehaviourTest(t, "race_test.go", []string{
+		"github.com/phildrip/toe/testdata/input/simple.MyInterface",
+		"github.com/phildrip/toe/testdata/input/variadic.Logger",
+	}, "-race")
+}
+
+// TestBlockingFunc runs a test calling a generated stub while one of its XFunc fields
+// blocks, under the race detector.
+func TestBlockingFunc(t *testing.T) {
+	runBehaviourTest(t, "blocking_test.go", []string{"github.com/phildrip/toe/testdata/input/simple.MyInterface"}, "-race")
+}
+
+// TestReset runs a test resetting generated stubs.
+func TestReset(t *testing.T) {
+	runBehaviourTest(t, "reset_test.go", []string{"github.com/phildrip/toe/testdata/input/simple.MyInterface"})
+}
+
+// writeImplementsCheck writes a file to dir, in package packageName, asserting at
+// compile time that *stubType satisfies iface, which is imported from importPath unless
+// that is empty, and returns its path.
+func writeImplementsCheck(t *testing.T, dir, packageName, importPath, iface, stubType string) string {
+	t.Helper()
+	importDecl := ""
+	if importPath != "" {
+		importDecl = fmt.Sprintf("import %q\n\n", importPath)
+	}
+	src := fmt.Sprintf("package %s\n\n%svar _ %s = (*%s)(nil)\n",
+		packageName,
+		importDecl,
+		iface,
+		stubType)
+	path := filepath.Join(dir, "implements_check.go")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write implements check: %v", err)
+	}
+	return path
+}
+
+// writeModule writes files, keyed by their slash-separated path, into a fresh
+// temporary directory and returns it.
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	moduleDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(moduleDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	return moduleDir
+}
+
+// buildWithImplementsCheck builds stubFile, a stub in package stubs, together with a
+// check that *stubType satisfies iface, which is imported from importPath.
+func buildWithImplementsCheck(t *testing.T, stubFile, importPath, iface, stubType string) {
+	t.Helper()
+	checkPath := writeImplementsCheck(t, filepath.Dir(stubFile), "stubs", importPath, iface, stubType)
+	cmd := exec.Command("go", "build", stubFile, checkPath)
+	var buildStderr bytes.Buffer
+	cmd.Stderr = &buildStderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Generated code failed to compile: %v\nStderr: %s", err, buildStderr.String())
+	}
+}
+
+// generateDiff returns a unified diff from the generated output to the golden file.
 func generateDiff(a, b []byte) string {
-	// For simplicity, we'll just show both versions.
-	// TODO: Consider using a proper diffing library like github.com/sergi/go-diff for better diff output.
-	return fmt.Sprintf("--- Generated\n+++ Golden\n%s\n%s", string(a), string(b))
+	return unifiedDiff("generated", "golden", a, b)
+}
+
+// runBehaviourTest generates stubs for the interfaces named by selectors into a
+// temporary stubs package and runs the named test file from testdata/behaviour against
+// them with go test, passing goTestFlags.
+func runBehaviourTest(t *testing.T, testFile string, selectors []string, goTestFlags ...string) {
+	t.Helper()
+	stubDir := filepath.Join(t.TempDir(), "stubs")
+	stubPath := filepath.Join(stubDir, "stubs.go")
+	var outBuffer, errBuffer bytes.Buffer
+	args := append([]string{"toe", "-combine", "-o", stubPath}, selectors...)
+	if exitCode := run(&outBuffer, &errBuffer, args); exitCode != 0 {
+		t.Fatalf("toe exited with non-zero status: %d\nStderr: %s", exitCode, errBuffer.String())
+	}
+
+	source, err := os.ReadFile(filepath.Join("testdata", "behaviour", testFile))
+	if err != nil {
+		t.Fatalf("Failed to read behaviour test: %v", err)
+	}
+	testPath := filepath.Join(stubDir, testFile)
+	if err := os.WriteFile(testPath, source, 0644); err != nil {
+		t.Fatalf("Failed to write behaviour test: %v", err)
+	}
+
+	goArgs := append([]string{"test"}, goTestFlags...)
+	cmd := exec.Command("go", append(goArgs, stubPath, testPath)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Behaviour test %s failed: %v\n%s", testFile, err, output)
+	}
 }
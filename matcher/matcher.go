@@ -0,0 +1,83 @@
+// Package matcher matches the arguments of calls to generated stubs, so that a stub can
+// return different values depending on its input.
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// Matcher reports whether an argument matches.
+type Matcher interface {
+	Matches(arg any) bool
+	String() string
+}
+
+// matcherFunc is a Matcher described by desc.
+type matcherFunc struct {
+	match func(arg any) bool
+	desc  string
+}
+
+func (m matcherFunc) Matches(arg any) bool { return m.match(arg) }
+func (m matcherFunc) String() string       { return m.desc }
+
+// Any matches every argument.
+func Any() Matcher {
+	return matcherFunc{func(any) bool { return true }, "any"}
+}
+
+// Eq matches an argument equal to want under ==. Arguments of a different type, or that
+// are not comparable, such as a struct holding a slice in an interface field, never
+// match; Eq(nil) matches any nil argument.
+func Eq(want any) Matcher {
+	return matcherFunc{func(arg any) bool {
+		if want == nil {
+			return isNil(arg)
+		}
+		if reflect.TypeOf(arg) != reflect.TypeOf(want) ||
+			!reflect.ValueOf(want).Comparable() || !reflect.ValueOf(arg).Comparable() {
+			return false
+		}
+		return arg == want
+	}, fmt.Sprintf("eq(%#v)", want)}
+}
+
+// DeepEqual matches an argument deeply equal to want, as reported by reflect.DeepEqual.
+func DeepEqual(want any) Matcher {
+	return matcherFunc{func(arg any) bool {
+		return reflect.DeepEqual(arg, want)
+	}, fmt.Sprintf("deepEqual(%#v)", want)}
+}
+
+// Func matches an argument of type T for which pred returns true.
+func Func[T any](pred func(T) bool) Matcher {
+	return matcherFunc{func(arg any) bool {
+		t, ok := arg.(T)
+		return ok && pred(t)
+	}, fmt.Sprintf("func(%s)", reflect.TypeFor[T]())}
+}
+
+// Regexp matches a string argument containing a match of pattern. It panics if pattern
+// does not compile.
+func Regexp(pattern string) Matcher {
+	re := regexp.MustCompile(pattern)
+	return matcherFunc{func(arg any) bool {
+		s, ok := arg.(string)
+		return ok && re.MatchString(s)
+	}, fmt.Sprintf("regexp(%q)", pattern)}
+}
+
+// isNil reports whether arg is nil or holds a nil pointer, slice, map, channel, function
+// or interface.
+func isNil(arg any) bool {
+	if arg == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(arg); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+)
+
+// box is comparable as a type, but not when V holds an uncomparable value.
+type box struct {
+	V any
+}
+
+func TestMatchers(t *testing.T) {
+	var nilPointer *int
+	testCases := []struct {
+		Name    string
+		Matcher Matcher
+		Arg     any
+		Want    bool
+	}{
+		{"any", Any(), 42, true},
+		{"eq_equal", Eq(42), 42, true},
+		{"eq_different_value", Eq(42), 43, false},
+		{"eq_different_type", Eq(42), int64(42), false},
+		{"eq_not_comparable", Eq([]int{1}), []int{1}, false},
+		{"eq_not_comparable_value", Eq(box{V: []int{1}}), box{V: []int{1}}, false},
+		{"eq_not_comparable_arg", Eq(box{V: 1}), box{V: []int{1}}, false},
+		{"eq_comparable_value", Eq(box{V: 1}), box{V: 1}, true},
+		{"eq_nil", Eq(nil), nil, true},
+		{"eq_nil_pointer", Eq(nil), nilPointer, true},
+		{"eq_nil_not_nil", Eq(nil), 0, false},
+		{"deep_equal", DeepEqual([]int{1, 2}), []int{1, 2}, true},
+		{"deep_equal_different", DeepEqual([]int{1, 2}), []int{2, 1}, false},
+		{"func", Func(func(s string) bool { return len(s) > 2 }), "abc", true},
+		{"func_false", Func(func(s string) bool { return len(s) > 2 }), "ab", false},
+		{"func_wrong_type", Func(func(s string) bool { return true }), 1, false},
+		{"regexp", Regexp(`^ab+c$`), "abbc", true},
+		{"regexp_no_match", Regexp(`^ab+c$`), "ac", false},
+		{"regexp_not_string", Regexp(`1`), 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := tc.Matcher.Matches(tc.Arg); got != tc.Want {
+				t.Errorf("%s.Matches(%#v) = %v, want %v", tc.Matcher, tc.Arg, got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	var rules Rules[string]
+	rules.Add(Eq(1), Any()).Return("first")
+	rules.Add(Any(), Any()).Return("second")
+	rules.Add(Any())
+
+	for _, tc := range []struct {
+		Args   []any
+		Want   string
+		WantOK bool
+	}{
+		{[]any{1, "x"}, "first", true},
+		{[]any{2, "x"}, "second", true},
+		{[]any{2}, "", true}, // A rule without Return returns zero values
+		{[]any{1, 2, 3}, "", false},
+	} {
+		got, ok := rules.Match(tc.Args...)
+		if got != tc.Want || ok != tc.WantOK {
+			t.Errorf("Match(%v) = %q, %v, want %q, %v", tc.Args, got, ok, tc.Want, tc.WantOK)
+		}
+	}
+
+	rules.Reset()
+	if got, ok := rules.Match(1, "x"); ok {
+		t.Errorf("Match(1, x) after Reset = %q, %v, want no match", got, ok)
+	}
+}
+
+func TestRulesMatchBlockingMatcher(t *testing.T) {
+	var rules Rules[string]
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	rules.Add(Func(func(n int) bool {
+		if n == 1 {
+			close(entered)
+			<-release
+		}
+		return n == 1
+	})).Return("blocked")
+	rules.Add(Any()).Return("any")
+
+	result := make(chan string)
+	go func() {
+		got, _ := rules.Match(1)
+		result <- got
+	}()
+	<-entered
+
+	// Neither matching nor adding rules waits for the blocked matcher
+	done := make(chan string)
+	go func() {
+		rules.Add(Eq(3))
+		got, _ := rules.Match(2)
+		done <- got
+	}()
+	select {
+	case got := <-done:
+		if got != "any" {
+			t.Errorf("Match(2) = %q, want %q", got, "any")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Match blocked while another call's matcher was running")
+	}
+
+	close(release)
+	if got := <-result; got != "blocked" {
+		t.Errorf("Match(1) = %q, want %q", got, "blocked")
+	}
+}
@@ -0,0 +1,73 @@
+package matcher
+
+import "sync"
+
+// Rules holds the rules of a stub method, which are tried in the order they were added.
+// Its zero value has no rules and is ready to use; it is safe for concurrent use.
+type Rules[R any] struct {
+	mu    sync.RWMutex
+	rules []*Rule[R]
+}
+
+// Rule makes calls whose arguments match its matchers return the values set with Return.
+type Rule[R any] struct {
+	rules    *Rules[R]
+	matchers []Matcher
+	values   R
+}
+
+// Add adds a rule matching calls with one argument per matcher.
+func (rs *Rules[R]) Add(matchers ...Matcher) *Rule[R] {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rule := &Rule[R]{rules: rs, matchers: matchers}
+	rs.rules = append(rs.rules, rule)
+	return rule
+}
+
+// Return sets the values returned by calls matching r, which are zero until it is called.
+func (r *Rule[R]) Return(values R) {
+	r.rules.mu.Lock()
+	defer r.rules.mu.Unlock()
+	r.values = values
+}
+
+// Reset removes every rule.
+func (rs *Rules[R]) Reset() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.rules = nil
+}
+
+// Match returns the values of the first rule whose matchers all match args, and whether
+// there was one. The matchers run on a copy of the rules without holding the lock, so a
+// matcher that blocks holds up no other call.
+func (rs *Rules[R]) Match(args ...any) (R, bool) {
+	rs.mu.RLock()
+	rules := make([]Rule[R], len(rs.rules))
+	for i, rule := range rs.rules {
+		rules[i] = *rule
+	}
+	rs.mu.RUnlock()
+
+	for _, rule := range rules {
+		if rule.matches(args) {
+			return rule.values, true
+		}
+	}
+	var zero R
+	return zero, false
+}
+
+// matches reports whether every matcher of r matches the corresponding argument.
+func (r *Rule[R]) matches(args []any) bool {
+	if len(args) != len(r.matchers) {
+		return false
+	}
+	for i, m := range r.matchers {
+		if !m.Matches(args[i]) {
+			return false
+		}
+	}
+	return true
+}
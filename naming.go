@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+)
+
+// StubNaming holds the text/template templates naming the declarations generated for a
+// stub. Every template may refer to {{.Name}}, the interface name, and all but Stub to
+// {{.Stub}}, the stub type name; those naming per-method declarations may also refer to
+// {{.Method}}. Empty templates take their value from DefaultStubNaming. Results is not a
+// template but the policy naming the fields of each returns type, one of the
+// ResultNames constants.
+type StubNaming struct {
+	Stub        string // Stub type, e.g. "Fake{{.Name}}"
+	Constructor string // Constructor function, e.g. "New{{.Stub}}"
+	Func        string // Field holding a method's function, e.g. "{{.Method}}Stub"
+	Calls       string // Field recording a method's calls
+	Returns     string // Field holding a method's fixed return values
+	CallType    string // Type of each recorded call
+	ReturnsType string // Type of a method's fixed return values
+	Results     string // Result field policy, e.g. ResultNamesIndex
+}
+
+// Policies naming the fields of a returns type, one per result.
+const (
+	// ResultNamesType names a result after its capitalised name, or after its base type
+	// and position if it is unnamed or blank (e.g. Int0, Error1).
+	ResultNamesType = "type"
+	// ResultNamesIndex names every result after its position alone (e.g. R0, R1).
+	ResultNamesIndex = "index"
+	// ResultNamesNamed names a result after its capitalised name, or after its position
+	// if it is unnamed or blank.
+	ResultNamesNamed = "named"
+)
+
+// DefaultStubNaming names stubs as toe always has.
+var DefaultStubNaming = StubNaming{
+	Stub:        "Stub{{.Name}}",
+	Constructor: "New{{.Stub}}",
+	Func:        "{{.Method}}Func",
+	Calls:       "{{.Method}}Calls",
+	Returns:     "{{.Method}}Returns",
+	CallType:    "{{.Stub}}{{.Method}}Call",
+	ReturnsType: "{{.Stub}}{{.Method}}Returns",
+	Results:     ResultNamesType,
+}
+
+// withDefaults returns n with each empty template replaced by its default.
+func (n StubNaming) withDefaults() StubNaming {
+	for _, pair := range []struct{ template, fallback *string }{
+		{&n.Stub, &DefaultStubNaming.Stub},
+		{&n.Constructor, &DefaultStubNaming.Constructor},
+		{&n.Func, &DefaultStubNaming.Func},
+		{&n.Calls, &DefaultStubNaming.Calls},
+		{&n.Returns, &DefaultStubNaming.Returns},
+		{&n.CallType, &DefaultStubNaming.CallType},
+		{&n.ReturnsType, &DefaultStubNaming.ReturnsType},
+		{&n.Results, &DefaultStubNaming.Results},
+	} {
+		if *pair.template == "" {
+			*pair.template = *pair.fallback
+		}
+	}
+	return n
+}
+
+// templates parses each template, keyed by the kind of name it produces.
+func (n StubNaming) templates() (map[string]*template.Template, error) {
+	n = n.withDefaults()
+	parsed := make(map[string]*template.Template)
+	for _, named := range []struct{ kind, text string }{
+		{"stub", n.Stub},
+		{"constructor", n.Constructor},
+		{"func field", n.Func},
+		{"calls field", n.Calls},
+		{"returns field", n.Returns},
+		{"call type", n.CallType},
+		{"returns type", n.ReturnsType},
+	} {
+		tmpl, err := template.New(named.kind + " name").Parse(named.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s name template %q: %v", named.kind, named.text, err)
+		}
+		parsed[named.kind] = tmpl
+	}
+	return parsed, nil
+}
+
+// Validate reports an error if any of the templates cannot be parsed or the result
+// naming policy is unknown.
+func (n StubNaming) Validate() error {
+	switch n.withDefaults().Results {
+	case ResultNamesType, ResultNamesIndex, ResultNamesNamed:
+	default:
+		return fmt.Errorf("invalid result naming %q; must be %s, %s or %s",
+			n.Results, ResultNamesType, ResultNamesIndex, ResultNamesNamed)
+	}
+	_, err := n.templates()
+	return err
+}
+
+// stubNames are the names of the declarations generated for a single stub, and of the
+// identifiers its methods use internally.
+type stubNames struct {
+	Stub         string
+	Constructor  string
+	ConstructorT string                 // Constructor taking a testing.TB
+	Verify       string                 // Method verifying expected calls
+	Reset        string                 // Method resetting the whole stub
+	ResetCalls   string                 // Method forgetting the calls to every method
+	Methods      map[string]methodNames // Keyed by method name
+
+	Receiver string // Receiver of the stub's methods
+	Opts     string // Options parameter of the constructor
+	Mutex    string // Field guarding the stub
+	Locked   string // Field recording whether the stub locks
+	Options  string // Field holding the stub's options
+	Expected string // Field holding the stub's expected calls
+	Call     string // Parameter numbering a call, in helpers setting return values
+	Values   string // Parameter holding return values, in those helpers
+	T        string // Parameter holding the test, in ConstructorT and Verify
+
+	// Locals of the stub's methods, which copy what they need under the lock before
+	// calling anything that could block or fail
+	Func       string // MethodNameFunc when called
+	Result     string // Values returned unless a rule or MethodNameFunc takes precedence
+	Matched    string // Values of the first rule matching the arguments
+	Sequenced  string // Whether the sequence held values for the call
+	Unexpected string // Whether a strict stub must fail the call
+}
+
+// methodNames are the names of the declarations generated for a single method.
+type methodNames struct {
+	Func         string
+	Calls        string
+	Returns      string
+	CallType     string
+	ReturnsType  string
+	CallFields   []string // Field of the call type recording each parameter
+	ResultFields []string // Field of the returns type holding each result
+
+	// Helper setting the expected number of calls
+	Expect string
+
+	// Helper forgetting the calls to the method and everything configured for it
+	Reset string
+
+	// Accessors reading recorded calls under the stub's lock; CallArgs is only set for
+	// methods with parameters
+	CallCount     string
+	CallArgs      string
+	CallsSnapshot string
+
+	// Helpers setting sequenced and argument-matched return values, and the fields
+	// holding them; only set for methods with results
+	SetReturns      string
+	ReturnsSet      string // Field recording whether SetReturns was called
+	ReturnsOnCall   string
+	ReturnsSequence string
+	Sequence        string
+	When            string
+	Rules           string
+}
+
+// nameTemplateData is the data naming templates are executed with.
+type nameTemplateData struct {
+	Name   string
+	Stub   string
+	Method string
+}
+
+// bodyIdentifiers are the predeclared identifiers referred to by the bodies of stub
+// methods, which parameters must not shadow.
+var bodyIdentifiers = []string{"append", "len", "nil"}
+
+// receiverNames are the preferred names for the receiver of stub methods, in order.
+var receiverNames = []string{"s", "stub"}
+
+// uniqueName returns name, followed by as many underscores as are needed for it not to
+// be taken. Every clashing identifier in a stub is made unique this way.
+func uniqueName(name string, taken map[string]bool) string {
+	for taken[name] {
+		name += "_"
+	}
+	return name
+}
+
+// resolveStubNames executes the naming templates of ifaceData for the stub and each of
+// its methods, whose parameters must already be named. The interface's method names
+// are fixed, so a generated field clashing with one of them, or with a field of another
+// method, is renamed deterministically; so are the stub's internal fields, the receiver
+// and the constructor's options parameter. It reports an error if the templates name
+// two declarations of the same method alike or after the method itself, which no
+// renaming of a particular interface could fix.
+func resolveStubNames(ifaceData *InterfaceData, methods []MethodData) (*stubNames, error) {
+	tmpls, err := ifaceData.Naming.templates()
+	if err != nil {
+		return nil, err
+	}
+	data := nameTemplateData{Name: ifaceData.Name}
+
+	names := &stubNames{Methods: make(map[string]methodNames, len(methods))}
+	if names.Stub, err = executeNameTemplate(tmpls["stub"], data); err != nil {
+		return nil, err
+	}
+	data.Stub = names.Stub
+	if names.Constructor, err = executeNameTemplate(tmpls["constructor"], data); err != nil {
+		return nil, err
+	}
+	names.ConstructorT = names.Constructor + "T"
+
+	// Struct fields share a namespace with the stub's methods
+	members := make(map[string]bool)
+	for _, method := range methods {
+		members[method.Name] = true
+	}
+	for _, method := range methods {
+		data.Method = method.Name
+		var mn methodNames
+		ownNames := map[string]string{method.Name: "method " + method.Name}
+		for _, named := range []struct {
+			kind    string
+			name    *string
+			isField bool
+			needed  bool
+		}{
+			{"func field", &mn.Func, true, true},
+			{"calls field", &mn.Calls, true, true},
+			{"returns field", &mn.Returns, true, len(method.Results) > 0},
+			{"call type", &mn.CallType, false, true},
+			{"returns type", &mn.ReturnsType, false, len(method.Results) > 0},
+		} {
+			if !named.needed {
+				continue
+			}
+			if *named.name, err = executeNameTemplate(tmpls[named.kind], data); err != nil {
+				return nil, err
+			}
+			if existing, ok := ownNames[*named.name]; ok {
+				return nil, fmt.Errorf("the %s of %s.%s would be named %s, which clashes with %s",
+					named.kind,
+					names.Stub,
+					method.Name,
+					*named.name,
+					existing)
+			}
+			ownNames[*named.name] = fmt.Sprintf("the %s of %s", named.kind, method.Name)
+			if named.isField {
+				*named.name = uniqueName(*named.name, members)
+				members[*named.name] = true
+			}
+		}
+
+		// Call fields need not avoid what parameters do, but parameters whose names
+		// differ only in case would share one. A name of underscores alone, such as __,
+		// leaves nothing to title, so the field is named after the position instead.
+		callFields := make(map[string]bool)
+		for j, p := range method.Params {
+			base := strings.Title(strings.TrimRight(p.Name, "_"))
+			if base == "" {
+				base = fmt.Sprintf("P%d", j)
+			}
+			field := uniqueName(base, callFields)
+			callFields[field] = true
+			mn.CallFields = append(mn.CallFields, field)
+		}
+		mn.ResultFields = resultFieldNames(method.Results, ifaceData.Naming.withDefaults().Results)
+		names.Methods[method.Name] = mn
+	}
+
+	// Helpers and internal fields are named after the templated fields, so that a
+	// clash renames them rather than the fields
+	type helper struct {
+		name *string
+		base string
+	}
+	for _, method := range methods {
+		mn := names.Methods[method.Name]
+		helpers := []helper{
+			{&mn.Expect, "Expect" + method.Name},
+			{&mn.CallCount, method.Name + "CallCount"},
+			{&mn.CallsSnapshot, method.Name + "CallsSnapshot"},
+			{&mn.Reset, "Reset" + method.Name},
+		}
+		if len(method.Params) > 0 {
+			helpers = append(helpers, helper{&mn.CallArgs, method.Name + "CallArgs"})
+		}
+		if len(method.Results) > 0 {
+			helpers = append(helpers,
+				helper{&mn.SetReturns, "Set" + method.Name + "Returns"},
+				helper{&mn.ReturnsSet, lowerFirst(method.Name) + "ReturnsSet"},
+				helper{&mn.ReturnsOnCall, method.Name + "ReturnsOnCall"},
+				helper{&mn.ReturnsSequence, method.Name + "ReturnsSequence"},
+				helper{&mn.Sequence, lowerFirst(method.Name) + "Sequence"},
+				helper{&mn.When, "When" + method.Name},
+				helper{&mn.Rules, lowerFirst(method.Name) + "Rules"})
+		}
+		for _, helper := range helpers {
+			*helper.name = uniqueName(helper.base, members)
+			members[*helper.name] = true
+		}
+		names.Methods[method.Name] = mn
+	}
+	for _, internal := range []helper{
+		{&names.Verify, "VerifyExpectations"},
+		{&names.Reset, "Reset"},
+		{&names.ResetCalls, "ResetCalls"},
+		{&names.Mutex, "mu"},
+		{&names.Locked, "isLocked"},
+		{&names.Options, "opts"},
+		{&names.Expected, "expected"},
+	} {
+		*internal.name = uniqueName(internal.base, members)
+		members[*internal.name] = true
+	}
+
+	// The receiver and options parameter must not shadow, or be shadowed by, the
+	// parameters and type parameters in scope alongside them
+	locals := make(map[string]bool)
+	for _, tp := range ifaceData.TypeParams {
+		locals[tp.Name] = true
+	}
+	names.Opts = uniqueName("opts", locals)
+	names.Call = uniqueName("n", locals)
+	names.Values = uniqueName("returns", locals)
+	names.T = uniqueName("t", locals)
+	for _, method := range methods {
+		for _, p := range method.Params {
+			locals[p.Name] = true
+		}
+	}
+	for _, local := range []helper{
+		{&names.Func, "fn"},
+		{&names.Result, "returns"},
+		{&names.Matched, "matched"},
+		{&names.Sequenced, "sequenced"},
+		{&names.Unexpected, "unexpected"},
+	} {
+		*local.name = uniqueName(local.base, locals)
+		locals[*local.name] = true
+	}
+	names.Receiver = receiverNames[0]
+	for i := 1; locals[names.Receiver] && i < len(receiverNames); i++ {
+		names.Receiver = receiverNames[i]
+	}
+	names.Receiver = uniqueName(names.Receiver, locals)
+	return names, nil
+}
+
+// resultFieldNames names the fields of a returns type under the given policy. Names the
+// interface gives its results take precedence, in order, over those derived from a type
+// or position; any that would coincide once capitalised, as a and A do, are made
+// unique.
+func resultFieldNames(results []ResultData, policy string) []string {
+	fields := make([]string, len(results))
+	taken := make(map[string]bool)
+	if policy != ResultNamesIndex {
+		for i, r := range results {
+			if r.Name == "" || r.Name == "_" {
+				continue
+			}
+			fields[i] = uniqueName(strings.Title(r.Name), taken)
+			taken[fields[i]] = true
+		}
+	}
+	for i, r := range results {
+		if fields[i] != "" {
+			continue
+		}
+		field := fmt.Sprintf("R%d", i)
+		if policy == ResultNamesType {
+			field = strings.Title(getBaseTypeName(r.Type)) + fmt.Sprintf("%d", i)
+		}
+		fields[i] = uniqueName(field, taken)
+		taken[fields[i]] = true
+	}
+	return fields
+}
+
+// executeNameTemplate renders a naming template and checks that the result is a valid
+// Go identifier.
+func executeNameTemplate(tmpl *template.Template, data any) (string, error) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid %s template: %v", tmpl.Name(), err)
+	}
+	name := buf.String()
+	if !token.IsIdentifier(name) || name == "_" {
+		return "", fmt.Errorf("%s template produced %q, which is not a valid Go identifier", tmpl.Name(), name)
+	}
+	return name, nil
+}
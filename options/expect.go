@@ -0,0 +1,135 @@
+package options
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Expectations holds the number of calls expected of each method of a stub. Its zero
+// value expects nothing and is ready to use; it is safe for concurrent use.
+type Expectations struct {
+	mu           sync.Mutex
+	expectations []*Expectation // In the order they were made
+}
+
+// Expectation is the number of calls expected of a stub method. A new expectation is for
+// at least one call.
+type Expectation struct {
+	expectations *Expectations
+	method       string
+	min, max     int // max is -1 if there is no maximum
+}
+
+// Expect returns a new expectation of calls to method, named as Stub.Method, replacing
+// any made before.
+func (es *Expectations) Expect(method string) *Expectation {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	e := &Expectation{expectations: es, method: method, min: 1, max: -1}
+	for i, existing := range es.expectations {
+		if existing.method == method {
+			es.expectations[i] = e
+			return e
+		}
+	}
+	es.expectations = append(es.expectations, e)
+	return e
+}
+
+// Forget discards the expectation of calls to method, if there is one.
+func (es *Expectations) Forget(method string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	for i, existing := range es.expectations {
+		if existing.method == method {
+			es.expectations = append(es.expectations[:i], es.expectations[i+1:]...)
+			return
+		}
+	}
+}
+
+// Allows reports whether an expectation of calls to method permits at least one call.
+func (es *Expectations) Allows(method string) bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	for _, e := range es.expectations {
+		if e.method == method {
+			return e.max != 0
+		}
+	}
+	return false
+}
+
+// Times expects exactly n calls.
+func (e *Expectation) Times(n int) *Expectation {
+	return e.set(n, n)
+}
+
+// AtLeast expects n or more calls.
+func (e *Expectation) AtLeast(n int) *Expectation {
+	return e.set(n, -1)
+}
+
+// AtMost expects no more than n calls.
+func (e *Expectation) AtMost(n int) *Expectation {
+	return e.set(0, n)
+}
+
+// Never expects no calls.
+func (e *Expectation) Never() *Expectation {
+	return e.set(0, 0)
+}
+
+func (e *Expectation) set(min, max int) *Expectation {
+	e.expectations.mu.Lock()
+	defer e.expectations.mu.Unlock()
+	e.min, e.max = min, max
+	return e
+}
+
+// Verify reports each expectation not met by calls, the number of calls to each method
+// keyed by the name it was expected with, using t.Errorf.
+func (es *Expectations) Verify(t testing.TB, calls map[string]int) {
+	t.Helper()
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	for _, e := range es.expectations {
+		if n := calls[e.method]; n < e.min || e.max >= 0 && n > e.max {
+			t.Errorf("%s must %s, but was %s", e.method, e.describe(), describeCalls(n))
+		}
+	}
+}
+
+// describe describes the calls e expects, e.g. "be called exactly twice".
+func (e *Expectation) describe() string {
+	switch {
+	case e.max == 0:
+		return "not be called"
+	case e.min == e.max:
+		return "be called exactly " + countCalls(e.min)
+	case e.max < 0:
+		return "be called at least " + countCalls(e.min)
+	default:
+		return "be called at most " + countCalls(e.max)
+	}
+}
+
+// describeCalls describes how many times a method was called, e.g. "called once".
+func describeCalls(n int) string {
+	if n == 0 {
+		return "not called"
+	}
+	return "called " + countCalls(n)
+}
+
+// countCalls spells out a number of calls.
+func countCalls(n int) string {
+	switch n {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	}
+	return fmt.Sprintf("%d times", n)
+}
@@ -0,0 +1,35 @@
+package options
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fail reports a failed call to a stub: with T.Fatal if T is set, and otherwise by
+// panicking with msg.
+func (o StubOptions) Fail(msg string) {
+	if o.T == nil {
+		panic(msg)
+	}
+	o.T.Helper()
+	o.T.Fatal(msg)
+}
+
+// Unexpected fails an unconfigured call to method, named as Stub.Method, with args.
+func (o StubOptions) Unexpected(method string, args ...any) {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		formatted[i] = fmt.Sprintf("%#v", arg)
+	}
+	if o.T != nil {
+		o.T.Helper()
+	}
+	o.Fail(fmt.Sprintf("unexpected call to %s(%s)", method, strings.Join(formatted, ", ")))
+}
+
+// IsZero reports whether v is the zero value of its type, as fixed return values that
+// were never set are.
+func IsZero[T any](v T) bool {
+	return reflect.ValueOf(&v).Elem().IsZero()
+}
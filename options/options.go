@@ -1,6 +1,24 @@
 package options
 
+import "testing"
+
 // StubOptions allows configuring aspects of the generated stub.
 type StubOptions struct{
 	WithLocking bool
+
+	// WhenExhausted says what a method returns once it has been called past the end
+	// of the values set with MethodNameReturnsSequence; by default the last values
+	// are repeated.
+	WhenExhausted Exhaustion
+
+	// Strict makes a call to a method with results that has no function, rules,
+	// sequenced values or fixed values fail, rather than silently return zero values.
+	// Fixed values count if they are non-zero or were set with SetMethodNameReturns.
+	// A call to a method without results fails unless it has a function or calls to
+	// it are expected.
+	Strict bool
+
+	// T, if set, is failed with Fatal when a call fails; otherwise the stub panics.
+	// As with any use of Fatal, calls must then be made from the test's goroutine.
+	T testing.TB
 }
@@ -0,0 +1,80 @@
+package options
+
+// Exhaustion says what a stub method returns once it has been called past the end of
+// its run of sequenced return values.
+type Exhaustion int
+
+const (
+	// RepeatLast returns the last values of the run again.
+	RepeatLast Exhaustion = iota
+	// ZeroValue returns the zero value of every result.
+	ZeroValue
+	// Fail fails the call, as reported by StubOptions.Fail.
+	Fail
+)
+
+// Sequence holds the values a stub method returns on particular calls, numbered from 0:
+// those set for a single call, and a run of values returned by consecutive calls. Its
+// zero value is empty and ready to use; generated stubs guard it with their lock.
+type Sequence[R any] struct {
+	onCall map[int]R // Values set for a single call
+	first  int       // Call returning the first of values
+	values []R       // Values returned by consecutive calls, from first
+}
+
+// Set makes call, and no other, return values.
+func (q *Sequence[R]) Set(call int, values R) {
+	if q.onCall == nil {
+		q.onCall = make(map[int]R)
+	}
+	q.onCall[call] = values
+}
+
+// Replace discards the values of every call and makes calls first, first+1, ... return
+// each of values in turn.
+func (q *Sequence[R]) Replace(first int, values []R) {
+	q.onCall = nil
+	q.first = first
+	q.values = append([]R(nil), values...)
+}
+
+// Rebase renumbers the run of values for calls numbered from 0 again once the first
+// calls calls are forgotten, so that it continues with the next call. Values set for a
+// single call keep their number.
+func (q *Sequence[R]) Rebase(calls int) {
+	q.first -= calls
+}
+
+// Reset discards the values of every call.
+func (q *Sequence[R]) Reset() {
+	q.Replace(0, nil)
+}
+
+// Empty reports whether no call has sequenced values.
+func (q *Sequence[R]) Empty() bool {
+	return len(q.onCall) == 0 && len(q.values) == 0
+}
+
+// ForCall returns the values for call: those set for it alone, else those of the run of
+// values, else fallback. Past the end of a run, the values are chosen by exhausted, and
+// it returns false if exhausted is Fail.
+func (q *Sequence[R]) ForCall(call int, fallback R, exhausted Exhaustion) (R, bool) {
+	if values, ok := q.onCall[call]; ok {
+		return values, true
+	}
+	if len(q.values) == 0 || call < q.first {
+		return fallback, true
+	}
+	if i := call - q.first; i < len(q.values) {
+		return q.values[i], true
+	}
+	var zero R
+	switch exhausted {
+	case ZeroValue:
+		return zero, true
+	case Fail:
+		return zero, false
+	default:
+		return q.values[len(q.values)-1], true
+	}
+}
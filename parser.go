@@ -3,8 +3,12 @@ package main
 import (
 	"fmt"
 	"go/types"
+	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -12,11 +16,22 @@ import (
 func collectImports(data *InterfaceData, t types.Type) {
 	switch typ := t.(type) {
 	case *types.Named:
-		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != data.PackageName {
+		// Named types are referenced by name only, so their underlying type
+		// contributes no imports of its own.
+		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != data.PackagePath {
 			data.Imports[typ.Obj().Pkg().Path()] = typ.Obj().Pkg().Name()
 		}
-		// Also check underlying type, e.g., for struct fields of named types
-		collectImports(data, typ.Underlying())
+		// Type arguments of instantiated generic types, e.g. atomic.Pointer[Config]
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			collectImports(data, typ.TypeArgs().At(i))
+		}
+	case *types.Alias:
+		if typ.Obj().Pkg() != nil && typ.Obj().Pkg().Path() != data.PackagePath {
+			data.Imports[typ.Obj().Pkg().Path()] = typ.Obj().Pkg().Name()
+		}
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			collectImports(data, typ.TypeArgs().At(i))
+		}
 	case *types.Pointer:
 		collectImports(data, typ.Elem())
 	case *types.Slice:
@@ -42,33 +57,230 @@ func collectImports(data *InterfaceData, t types.Type) {
 	case *types.Basic:
 		// Basic types do not have associated packages.
 	case *types.Interface:
-		// Interface types themselves might not directly reference packages unless embedded named types.
-		// Handled by recursive calls to collectImports on method signatures.
+		for i := 0; i < typ.NumEmbeddeds(); i++ {
+			collectImports(data, typ.EmbeddedType(i))
+		}
+		for i := 0; i < typ.NumExplicitMethods(); i++ {
+			collectImports(data, typ.ExplicitMethod(i).Type())
+		}
+	case *types.Union:
+		for i := 0; i < typ.Len(); i++ {
+			collectImports(data, typ.Term(i).Type())
+		}
+	case *types.Struct:
+		for i := 0; i < typ.NumFields(); i++ {
+			collectImports(data, typ.Field(i).Type())
+		}
 	case *types.TypeParam:
 		// Type parameters themselves don't directly reference packages, but their constraints might.
 		collectImports(data, typ.Constraint())
 	}
 }
 
-func FindInterface(inputDir string,
-	interfaceName string,
-	stubDir string) (*InterfaceData, error) {
+// collectMethods appends the methods of iface to data.Methods: first those declared
+// directly on it, then those of each embedded interface in declaration order. origin
+// names the interface being walked ("" for the top-level interface) and seen skips
+// methods already provided through another embedding.
+func collectMethods(data *InterfaceData,
+	iface *types.Interface,
+	origin string,
+	qualifier types.Qualifier,
+	seen map[string]bool) {
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		method := iface.ExplicitMethod(i)
+		if seen[method.Name()] {
+			continue
+		}
+		seen[method.Name()] = true
+		data.Methods = append(data.Methods, newMethodData(data, method, origin))
+	}
+
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		embedded := iface.EmbeddedType(i)
+		embeddedIface, ok := embedded.Underlying().(*types.Interface)
+		if !ok {
+			continue // Type set terms such as ~int contribute no methods
+		}
+		embeddedOrigin := origin
+		switch embedded.(type) {
+		case *types.Named, *types.Alias:
+			// Instantiated generics carry their type arguments, e.g. Getter[T]
+			embeddedOrigin = types.TypeString(embedded, qualifier)
+		}
+		collectMethods(data, embeddedIface, embeddedOrigin, qualifier, seen)
+	}
+}
+
+// newMethodData converts a method's signature to MethodData, collecting the imports it needs.
+func newMethodData(data *InterfaceData, method *types.Func, origin string) MethodData {
+	sig := method.Type().(*types.Signature)
+
+	methodData := MethodData{
+		Name:   method.Name(),
+		Origin: origin,
+	}
+
+	// Parameters
+	if sig.Params() != nil {
+		for j := 0; j < sig.Params().Len(); j++ {
+			param := sig.Params().At(j)
+			methodData.Params = append(methodData.Params, ParamData{
+				Name:     param.Name(),
+				Type:     param.Type(), // Store types.Type directly
+				Variadic: sig.Variadic() && j == sig.Params().Len()-1,
+			})
+			collectImports(data, param.Type())
+		}
+	}
+
+	// Results
+	if sig.Results() != nil {
+		for j := 0; j < sig.Results().Len(); j++ {
+			result := sig.Results().At(j)
+			methodData.Results = append(methodData.Results, ResultData{
+				Name: result.Name(),
+				Type: result.Type(), // Store types.Type directly
+			})
+			collectImports(data, result.Type())
+		}
+	}
+	return methodData
+}
+
+// packagePathForDir returns the import path a package in dir would have, found by
+// locating the enclosing go.mod. It returns "" if dir is not inside a module.
+func packagePathForDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for modDir := absDir; ; modDir = filepath.Dir(modDir) {
+		content, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+		if err == nil {
+			modulePath := modfile.ModulePath(content)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module path in %s", filepath.Join(modDir, "go.mod"))
+			}
+			rel, err := filepath.Rel(modDir, absDir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if filepath.Dir(modDir) == modDir {
+			return "", nil
+		}
+	}
+}
+
+// LoadPackages loads the packages matching patterns, resolved relative to dir, with the
+// type information needed to extract interfaces from them. Patterns are standard Go
+// package patterns such as ".", "./..." or "github.com/org/repo/store".
+func LoadPackages(dir string, patterns ...string) ([]*packages.Package, error) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedFiles |
 			packages.NeedSyntax |
 			packages.NeedTypes |
-			packages.NeedTypesInfo,
-		Dir: inputDir,
+			packages.NeedTypesInfo |
+			packages.NeedImports |
+			packages.NeedDeps,
+		Dir: dir,
 	}
-	pkgs, err := packages.Load(cfg, ".")
+	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
 		return nil, fmt.Errorf("load: %v", err)
 	}
 	if packages.PrintErrors(pkgs) > 0 {
 		return nil, fmt.Errorf("packages contain errors")
 	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages match %s", strings.Join(patterns, " "))
+	}
+	return pkgs, nil
+}
+
+// ExportedInterfaces returns the sorted names of the exported interfaces in pkgs that
+// can be stubbed, skipping constraint-only interfaces and those with unexported methods.
+func ExportedInterfaces(pkgs []*packages.Package) []string {
+	var names []string
+	for _, pkg := range pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() { // Names are returned sorted
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !obj.Exported() || obj.IsAlias() {
+				continue
+			}
+			iface, ok := obj.Type().Underlying().(*types.Interface)
+			if !ok || !iface.IsMethodSet() {
+				continue
+			}
+			stubbable := true
+			for i := 0; i < iface.NumMethods(); i++ {
+				if !iface.Method(i).Exported() {
+					stubbable = false
+				}
+			}
+			if stubbable {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// HasInterface reports whether pkg declares an interface named interfaceName. It returns
+// an error if the interface is a type constraint, such as cmp.Ordered, which no stub
+// could satisfy.
+func HasInterface(pkg *packages.Package, interfaceName string) (bool, error) {
+	obj := pkg.Types.Scope().Lookup(interfaceName)
+	if obj == nil {
+		return false, nil
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return false, nil
+	}
+	if !iface.IsMethodSet() {
+		return false, fmt.Errorf("interface %s.%s is a type constraint and cannot be stubbed",
+			pkg.PkgPath,
+			interfaceName)
+	}
+	return true, nil
+}
 
+// PackageDir returns the directory containing pkg's source files.
+func PackageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) == 0 {
+		return ""
+	}
+	return filepath.Dir(pkg.GoFiles[0])
+}
+
+// FindInterface loads the package in inputDir and extracts interfaceName. outputDir is
+// the directory the stub will be written to: if it is the interface's own package
+// directory the stub joins that package, otherwise it is named after outputDir and
+// refers to the source package's types through an import.
+func FindInterface(inputDir string,
+	interfaceName string,
+	outputDir string) (*InterfaceData, error) {
+	pkgs, err := LoadPackages(inputDir, ".")
+	if err != nil {
+		return nil, err
+	}
+	return FindInterfaceInPackages(pkgs, interfaceName, outputDir)
+}
+
+// FindInterfaceInPackages extracts interfaceName from already loaded packages. See FindInterface.
+func FindInterfaceInPackages(pkgs []*packages.Package,
+	interfaceName string,
+	outputDir string) (*InterfaceData, error) {
 	var foundInterface *InterfaceData
 
 	for _, pkg := range pkgs {
@@ -88,19 +300,32 @@ func FindInterface(inputDir string,
 		if foundInterface != nil {
 			return nil, fmt.Errorf("found duplicate interface %s in package %s and %s",
 				interfaceName,
-				foundInterface.PackageName,
-				pkg.Name)
+				foundInterface.SourcePackagePath,
+				pkg.PkgPath)
 		}
 
-		generatedPackageName := pkg.Name
-		if stubDir != "" {
-			generatedPackageName = filepath.Base(stubDir)
+		absOutputDir, err := filepath.Abs(outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve output directory: %v", err)
 		}
 
 		data := &InterfaceData{
-			PackageName: generatedPackageName,
-			Name:        interfaceName,
-			Imports:     make(map[string]string),
+			SourcePackageName: pkg.Name,
+			SourcePackagePath: pkg.PkgPath,
+			Name:              interfaceName,
+			Imports:           make(map[string]string),
+		}
+
+		if PackageDir(pkg) == absOutputDir {
+			// Generating into the interface's own package: its types need no qualifier
+			data.PackageName = pkg.Name
+			data.PackagePath = pkg.PkgPath
+		} else {
+			data.PackageName = filepath.Base(absOutputDir)
+			data.PackagePath, err = packagePathForDir(absOutputDir)
+			if err != nil {
+				return nil, fmt.Errorf("resolve output package: %v", err)
+			}
 		}
 
 		// Handle generic interfaces
@@ -115,41 +340,15 @@ func FindInterface(inputDir string,
 			}
 		}
 
-		for i := 0; i < ifaceType.NumMethods(); i++ {
-			method := ifaceType.Method(i)
-			sig := method.Type().(*types.Signature)
-
-			methodData := MethodData{
-				Name: method.Name(),
-			}
-
-			// Parameters
-			if sig.Params() != nil {
-				for j := 0; j < sig.Params().Len(); j++ {
-					param := sig.Params().At(j)
-					methodData.Params = append(methodData.Params, ParamData{
-						Name: param.Name(),
-						Type: param.Type(), // Store types.Type directly
-					})
-					collectImports(data, param.Type())
-				}
-			}
-
-			// Results
-			if sig.Results() != nil {
-				for j := 0; j < sig.Results().Len(); j++ {
-					result := sig.Results().At(j)
-					name := result.Name()
-					
-					methodData.Results = append(methodData.Results, ResultData{
-						Name: name,
-						Type: result.Type(), // Store types.Type directly
-					})
-					collectImports(data, result.Type())
-				}
+		// Walk the interface's own methods and then its embedded interfaces, so that
+		// each method records the interface that declared it
+		qualifier := func(p *types.Package) string {
+			if p == pkg.Types {
+				return ""
 			}
-			data.Methods = append(data.Methods, methodData)
+			return p.Name()
 		}
+		collectMethods(data, ifaceType, "", qualifier, make(map[string]bool))
 		foundInterface = data
 	}
 
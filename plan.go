@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// stubFile is a single generated file and the interfaces whose stubs it contains.
+type stubFile struct {
+	Path       string
+	Interfaces []*InterfaceData
+}
+
+// stubLayout describes where the stubs for a set of packages are written.
+type stubLayout struct {
+	StubDir    string // Directory stub files are written to
+	OutputFile string // Explicit output file (-o); every stub is written to it
+	Combine    bool   // Write the stubs of each package to a single file
+	PerPackage bool   // Resolve StubDir relative to each source package's directory
+
+	PackageName string     // Package name of stubs written outside the source package; defaults to the directory name
+	Naming      StubNaming // Templates naming the generated declarations
+}
+
+// packageSelection is a loaded package and the interfaces to stub from it.
+type packageSelection struct {
+	Pkg   *packages.Package
+	Names []string
+}
+
+// selectByName selects interfaceNames from whichever of pkgs declare them; each name
+// must be found in at least one package. With all set every exported interface in
+// each package is selected instead.
+func selectByName(pkgs []*packages.Package, interfaceNames []string, all bool) ([]packageSelection, error) {
+	var selections []packageSelection
+	found := make(map[string]bool)
+	for _, pkg := range pkgs {
+		selection := packageSelection{Pkg: pkg}
+		if all {
+			selection.Names = ExportedInterfaces([]*packages.Package{pkg})
+		}
+		for _, interfaceName := range interfaceNames {
+			has, err := HasInterface(pkg, interfaceName)
+			if err != nil {
+				return nil, err
+			}
+			if has {
+				found[interfaceName] = true
+				selection.Names = append(selection.Names, interfaceName)
+			}
+		}
+		if len(selection.Names) > 0 {
+			selections = append(selections, selection)
+		}
+	}
+
+	for _, interfaceName := range interfaceNames {
+		if !found[interfaceName] {
+			return nil, fmt.Errorf("interface %s not found", interfaceName)
+		}
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("no exported interfaces found")
+	}
+	return selections, nil
+}
+
+// parseInterfaceSelector splits a fully-qualified selector such as "io.ReadCloser" or
+// "net/http.RoundTripper" into its import path and interface name. It reports false if
+// arg does not end in an exported identifier qualified by an import path.
+func parseInterfaceSelector(arg string) (importPath, interfaceName string, ok bool) {
+	dot := strings.LastIndex(arg, ".")
+	if dot <= 0 || dot < strings.LastIndex(arg, "/") {
+		return "", "", false
+	}
+	importPath, interfaceName = arg[:dot], arg[dot+1:]
+	if !token.IsIdentifier(interfaceName) || !token.IsExported(interfaceName) || strings.HasSuffix(importPath, "/") {
+		return "", "", false
+	}
+	return importPath, interfaceName, true
+}
+
+// selectBySelector selects the interface named by each "importpath.Interface" selector
+// from the package with that import path.
+func selectBySelector(pkgs []*packages.Package, selectors []string) ([]packageSelection, error) {
+	pkgsByPath := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgsByPath[pkg.PkgPath] = pkg
+	}
+
+	var selections []packageSelection
+	indexByPath := make(map[string]int)
+	for _, selector := range selectors {
+		importPath, interfaceName, _ := parseInterfaceSelector(selector)
+		pkg, ok := pkgsByPath[importPath]
+		if !ok {
+			return nil, fmt.Errorf("interface %s not found", selector)
+		}
+		has, err := HasInterface(pkg, interfaceName)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, fmt.Errorf("interface %s not found", selector)
+		}
+		i, ok := indexByPath[importPath]
+		if !ok {
+			i = len(selections)
+			indexByPath[importPath] = i
+			selections = append(selections, packageSelection{Pkg: pkg})
+		}
+		selections[i].Names = append(selections[i].Names, interfaceName)
+	}
+	return selections, nil
+}
+
+// planStubFiles decides which file each selected interface's stub is written to.
+func planStubFiles(selections []packageSelection, layout stubLayout) ([]stubFile, error) {
+	var planner stubPlanner
+	if err := planner.add(selections, layout); err != nil {
+		return nil, err
+	}
+	return planner.stubFiles(), nil
+}
+
+// stubPlanner accumulates the stub files for one or more selections, so that stubs
+// selected separately can still be combined into the same file.
+type stubPlanner struct {
+	files       []*stubFile
+	filesByPath map[string]*stubFile
+}
+
+// add plans the stubs for selections laid out according to layout.
+func (p *stubPlanner) add(selections []packageSelection, layout stubLayout) error {
+	if p.filesByPath == nil {
+		p.filesByPath = make(map[string]*stubFile)
+	}
+
+	if err := layout.Naming.Validate(); err != nil {
+		return err
+	}
+
+	written := 0
+	for _, selection := range selections {
+		pkg := selection.Pkg
+
+		stubDir := layout.StubDir
+		if layout.PerPackage {
+			stubDir = filepath.Join(relativeToWorkingDir(PackageDir(pkg)), layout.StubDir)
+		}
+
+		for _, interfaceName := range selection.Names {
+			path := layout.OutputFile
+			if path == "" && layout.Combine {
+				path = filepath.Join(stubDir, fmt.Sprintf("stub_%s.go", strings.ToLower(pkg.Name)))
+			} else if path == "" {
+				path = filepath.Join(stubDir, fmt.Sprintf("stub_%s.go", strings.ToLower(interfaceName)))
+			}
+
+			interfaceData, err := FindInterfaceInPackages([]*packages.Package{pkg},
+				interfaceName,
+				filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			if layout.PackageName != "" && interfaceData.PackagePath != interfaceData.SourcePackagePath {
+				interfaceData.PackageName = layout.PackageName
+			}
+			interfaceData.Naming = layout.Naming
+
+			file, ok := p.filesByPath[path]
+			if !ok {
+				file = &stubFile{Path: path}
+				p.filesByPath[path] = file
+				p.files = append(p.files, file)
+			}
+			for _, existing := range file.Interfaces {
+				if existing.Name == interfaceName {
+					return fmt.Errorf("stubs for %s in %s and %s would both be written to %s",
+						interfaceName,
+						existing.SourcePackagePath,
+						interfaceData.SourcePackagePath,
+						path)
+				}
+			}
+			file.Interfaces = append(file.Interfaces, interfaceData)
+			written++
+		}
+	}
+
+	if layout.OutputFile != "" && !layout.Combine && written > 1 {
+		return fmt.Errorf("-o can only be used with a single interface unless -combine is set")
+	}
+	return nil
+}
+
+// stubFiles returns the planned files in the order they were first added.
+func (p *stubPlanner) stubFiles() []stubFile {
+	planned := make([]stubFile, len(p.files))
+	for i, file := range p.files {
+		planned[i] = *file
+	}
+	return planned
+}
+
+// relativeToWorkingDir returns dir relative to the working directory when it lies
+// beneath it, so that reported paths stay short.
+func relativeToWorkingDir(dir string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dir
+	}
+	return rel
+}
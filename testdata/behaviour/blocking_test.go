@@ -0,0 +1,50 @@
+package stubs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phildrip/toe/options"
+)
+
+// TestBlockingFunc calls a stub whose CalculateFunc blocks, which must not stop other
+// calls to the stub or reads of its calls from completing.
+func TestBlockingFunc(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{WithLocking: true})
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	stub.CalculateFunc = func(x, y int) (int, error) {
+		close(entered)
+		<-release
+		return x + y, nil
+	}
+
+	result := make(chan int)
+	go func() {
+		sum, _ := stub.Calculate(1, 2)
+		result <- sum
+	}()
+	<-entered
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stub.SetValue("v")
+		stub.GetValue()
+		stub.CalculateCallCount()
+		stub.SetValueCallsSnapshot()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("calls to the stub blocked while CalculateFunc was running")
+	}
+
+	close(release)
+	if sum := <-result; sum != 3 {
+		t.Errorf("Calculate(1, 2) = %d, want 3", sum)
+	}
+	if got := stub.CalculateCallCount(); got != 1 {
+		t.Errorf("CalculateCallCount() = %d, want 1", got)
+	}
+}
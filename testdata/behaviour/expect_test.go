@@ -0,0 +1,73 @@
+package stubs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/phildrip/toe/options"
+)
+
+// fakeTB records errors and cleanup functions instead of acting on them.
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// endTest runs the cleanup functions as the end of a test would.
+func (f *fakeTB) endTest() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestUnmetExpectationsReported(t *testing.T) {
+	tb := &fakeTB{}
+	stub := NewStubMyInterfaceT(tb, options.StubOptions{WithLocking: true})
+	stub.ExpectSetValue().Times(2)
+	stub.ExpectGetValue()
+	stub.ExpectCalculate().Never()
+	stub.SetValue("once")
+	stub.Calculate(1, 2)
+	stub.Calculate(3, 4)
+
+	if len(tb.errors) > 0 {
+		t.Fatalf("expectations verified before the test ended: %q", tb.errors)
+	}
+	tb.endTest()
+
+	want := []string{
+		"StubMyInterface.SetValue must be called exactly twice, but was called once",
+		"StubMyInterface.GetValue must be called at least once, but was not called",
+		"StubMyInterface.Calculate must not be called, but was called twice",
+	}
+	if fmt.Sprint(tb.errors) != fmt.Sprint(want) {
+		t.Errorf("errors = %q, want %q", tb.errors, want)
+	}
+}
+
+func TestMetExpectationsPass(t *testing.T) {
+	stub := NewStubMyInterfaceT(t, options.StubOptions{WithLocking: true})
+	stub.ExpectSetValue().AtLeast(2)
+	stub.ExpectGetValue().AtMost(1)
+	stub.ExpectCalculate().Times(1)
+	stub.ExpectCalculate().Never() // Replaces the expectation above
+	stub.SetValue("a")
+	stub.SetValue("b")
+	stub.SetValue("c")
+}
+
+func TestTestConstructorFailsTest(t *testing.T) {
+	tb := &fakeTB{}
+	stub := NewStubMyInterfaceT(tb, options.StubOptions{WithLocking: true})
+	if stub.opts.T != tb {
+		t.Errorf("stub reports failures to %v, want the test's %v", stub.opts.T, tb)
+	}
+}
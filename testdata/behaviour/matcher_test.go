@@ -0,0 +1,78 @@
+package stubs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/phildrip/toe/matcher"
+	"github.com/phildrip/toe/options"
+)
+
+var errNegative = errors.New("negative")
+
+func TestWhenMatchesArguments(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.WhenCalculate(matcher.Eq(1), matcher.Any()).Return(StubMyInterfaceCalculateReturns{Int0: 10})
+	stub.WhenCalculate(matcher.Func(func(x int) bool { return x < 0 }), matcher.Any()).
+		Return(StubMyInterfaceCalculateReturns{Error1: errNegative})
+	stub.CalculateReturns = StubMyInterfaceCalculateReturns{Int0: -1}
+
+	for _, tc := range []struct {
+		x, y    int
+		want    int
+		wantErr error
+	}{
+		{1, 5, 10, nil},
+		{-3, 5, 0, errNegative},
+		{2, 5, -1, nil}, // No rule matches, so CalculateReturns applies
+	} {
+		got, err := stub.Calculate(tc.x, tc.y)
+		if got != tc.want || err != tc.wantErr {
+			t.Errorf("Calculate(%d, %d) = %d, %v, want %d, %v", tc.x, tc.y, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestWhenFirstMatchWins(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.WhenCalculate(matcher.Any(), matcher.Eq(2)).Return(StubMyInterfaceCalculateReturns{Int0: 1})
+	stub.WhenCalculate(matcher.Eq(1), matcher.Eq(2)).Return(StubMyInterfaceCalculateReturns{Int0: 2})
+
+	if got, _ := stub.Calculate(1, 2); got != 1 {
+		t.Errorf("Calculate(1, 2) = %d, want the first rule's 1", got)
+	}
+}
+
+func TestWhenTakesPrecedenceOverFunc(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.CalculateFunc = func(x, y int) (int, error) { return x + y, nil }
+	stub.WhenCalculate(matcher.Eq(0), matcher.Eq(0)).Return(StubMyInterfaceCalculateReturns{Int0: 100})
+
+	if got, _ := stub.Calculate(0, 0); got != 100 {
+		t.Errorf("Calculate(0, 0) = %d, want the rule's 100", got)
+	}
+	if got, _ := stub.Calculate(1, 2); got != 3 {
+		t.Errorf("Calculate(1, 2) = %d, want CalculateFunc's 3", got)
+	}
+}
+
+func TestWhenMatchesStrings(t *testing.T) {
+	stub := NewStubLogger(options.StubOptions{})
+	stub.WhenJoin(matcher.DeepEqual([]string{"a", "b"})).Return(StubLoggerJoinReturns{String0: "deep"})
+	stub.WhenPrintf(matcher.Regexp("^err"), matcher.Any()).Return(StubLoggerPrintfReturns{Int0: 1})
+	stub.JoinFunc = func(parts ...string) string { return strings.Join(parts, "+") }
+
+	if got := stub.Join("a", "b"); got != "deep" {
+		t.Errorf("Join(a, b) = %q, want %q", got, "deep")
+	}
+	if got := stub.Join("a", "c"); got != "a+c" {
+		t.Errorf("Join(a, c) = %q, want %q", got, "a+c")
+	}
+	if got, _ := stub.Printf("error: %d", 1); got != 1 {
+		t.Errorf("Printf(error: %%d) = %d, want 1", got)
+	}
+	if got, _ := stub.Printf("warning: %d", 1); got != 0 {
+		t.Errorf("Printf(warning: %%d) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,88 @@
+package stubs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/phildrip/toe/options"
+)
+
+// TestConcurrentCallRecording reads a stub's calls while other goroutines are calling
+// it, which must be free of data races with locking enabled.
+func TestConcurrentCallRecording(t *testing.T) {
+	const producers, callsEach = 8, 100
+	stub := NewStubMyInterface(options.StubOptions{WithLocking: true})
+	stub.CalculateReturns = StubMyInterfaceCalculateReturns{Int0: 1}
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < callsEach; i++ {
+				stub.Calculate(p, i)
+				stub.SetValue("v")
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for reading := true; reading; {
+		select {
+		case <-done:
+			reading = false
+		default:
+		}
+		if n := stub.CalculateCallCount(); n > 0 {
+			if x, y := stub.CalculateCallArgs(n - 1); x < 0 || x >= producers || y < 0 || y >= callsEach {
+				t.Fatalf("CalculateCallArgs(%d) = %d, %d, which no producer passed", n-1, x, y)
+			}
+		}
+		snapshot := stub.SetValueCallsSnapshot()
+		for _, call := range snapshot {
+			if call.Val != "v" {
+				t.Fatalf("SetValueCallsSnapshot recorded %q, want %q", call.Val, "v")
+			}
+		}
+	}
+
+	if got := stub.CalculateCallCount(); got != producers*callsEach {
+		t.Errorf("CalculateCallCount() = %d, want %d", got, producers*callsEach)
+	}
+	if got := len(stub.SetValueCallsSnapshot()); got != producers*callsEach {
+		t.Errorf("len(SetValueCallsSnapshot()) = %d, want %d", got, producers*callsEach)
+	}
+}
+
+func TestCallsSnapshotIsCopy(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.SetValue("a")
+	snapshot := stub.SetValueCallsSnapshot()
+	snapshot[0].Val = "changed"
+	stub.SetValue("b")
+
+	if x := stub.GetValueCallsSnapshot(); x != nil {
+		t.Errorf("GetValueCallsSnapshot() = %v, want no calls", x)
+	}
+	if stub.SetValueCalls[0].Val != "a" {
+		t.Errorf("changing the snapshot changed the recorded call to %q", stub.SetValueCalls[0].Val)
+	}
+	if len(snapshot) != 1 {
+		t.Errorf("the snapshot grew to %d calls after it was taken", len(snapshot))
+	}
+}
+
+func TestCallArgsCopiesVariadicArgs(t *testing.T) {
+	stub := NewStubLogger(options.StubOptions{})
+	stub.Log("%d", 1)
+	_, args := stub.LogCallArgs(0)
+	args[0] = 2
+
+	if got := stub.LogCalls[0].Args[0]; got != 1 {
+		t.Errorf("changing the arguments returned by LogCallArgs changed the recorded argument to %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package stubs
+
+import (
+	"testing"
+
+	"github.com/phildrip/toe/matcher"
+	"github.com/phildrip/toe/options"
+)
+
+// configure records calls to every method of stub and configures all it can.
+func configure(stub *StubMyInterface) {
+	stub.CalculateReturns = StubMyInterfaceCalculateReturns{Int0: 1}
+	stub.CalculateReturnsOnCall(1, StubMyInterfaceCalculateReturns{Int0: 2})
+	stub.WhenCalculate(matcher.Eq(0), matcher.Any()).Return(StubMyInterfaceCalculateReturns{Int0: 3})
+	stub.GetValueFunc = func() string { return "v" }
+	stub.SetValueFunc = func(string) {}
+	stub.ExpectSetValue().Never()
+	stub.Calculate(1, 1)
+	stub.GetValue()
+	stub.SetValue("v")
+}
+
+func TestReset(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{WithLocking: true})
+	configure(stub)
+	stub.Reset()
+
+	if n := stub.CalculateCallCount() + stub.GetValueCallCount() + stub.SetValueCallCount(); n != 0 {
+		t.Errorf("%d calls recorded after Reset, want none", n)
+	}
+	if stub.GetValueFunc != nil || stub.SetValueFunc != nil {
+		t.Error("Reset kept a Func")
+	}
+	for x := range 3 {
+		if got, _ := stub.Calculate(x, 0); got != 0 {
+			t.Errorf("Calculate(%d, 0) after Reset = %d, want 0", x, got)
+		}
+	}
+	stub.SetValue("v")
+	stub.VerifyExpectations(t) // SetValue is no longer expected never to be called
+}
+
+func TestResetCalls(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	configure(stub)
+	stub.ResetCalls()
+
+	if n := stub.CalculateCallCount() + stub.GetValueCallCount() + stub.SetValueCallCount(); n != 0 {
+		t.Errorf("%d calls recorded after ResetCalls, want none", n)
+	}
+	// Calls are numbered from 0 again, so the value for call 1 applies to the next call
+	// but one
+	for i, want := range []int{3, 2} {
+		if got, _ := stub.Calculate(i, 0); got != want {
+			t.Errorf("Calculate(%d, 0) after ResetCalls = %d, want %d", i, got, want)
+		}
+	}
+	if got := stub.GetValue(); got != "v" {
+		t.Errorf("GetValue() after ResetCalls = %q, want %q", got, "v")
+	}
+}
+
+func TestResetCallsContinuesSequence(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.GetValue()
+	stub.GetValueReturnsSequence(
+		StubMyInterfaceGetValueReturns{String0: "first"},
+		StubMyInterfaceGetValueReturns{String0: "second"},
+		StubMyInterfaceGetValueReturns{String0: "third"},
+	)
+	stub.GetValue()
+	stub.ResetCalls()
+
+	for _, want := range []string{"second", "third", "third"} {
+		if got := stub.GetValue(); got != want {
+			t.Errorf("GetValue() after ResetCalls = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestResetMethod(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	configure(stub)
+	stub.ResetCalculate()
+
+	if n := stub.CalculateCallCount(); n != 0 {
+		t.Errorf("CalculateCallCount() after ResetCalculate = %d, want 0", n)
+	}
+	if got, _ := stub.Calculate(0, 0); got != 0 {
+		t.Errorf("Calculate(0, 0) after ResetCalculate = %d, want 0", got)
+	}
+	if n := stub.GetValueCallCount(); n != 1 {
+		t.Errorf("GetValueCallCount() after ResetCalculate = %d, want 1", n)
+	}
+	if got := stub.GetValue(); got != "v" {
+		t.Errorf("GetValue() after ResetCalculate = %q, want %q", got, "v")
+	}
+}
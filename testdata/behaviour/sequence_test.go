@@ -0,0 +1,108 @@
+package stubs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phildrip/toe/options"
+)
+
+var errTimeout = errors.New("timeout")
+
+func TestReturnsSequence(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.CalculateReturnsSequence(
+		StubMyInterfaceCalculateReturns{Error1: errTimeout},
+		StubMyInterfaceCalculateReturns{Int0: 42},
+	)
+
+	if _, err := stub.Calculate(1, 2); err != errTimeout {
+		t.Errorf("first call returned error %v, want %v", err, errTimeout)
+	}
+	for i := 0; i < 2; i++ {
+		// Once exhausted, the last values are repeated
+		if got, err := stub.Calculate(1, 2); got != 42 || err != nil {
+			t.Errorf("call %d returned %d, %v, want 42, nil", i+2, got, err)
+		}
+	}
+}
+
+func TestReturnsOnCall(t *testing.T) {
+	// Values set for a single call are not subject to WhenExhausted
+	stub := NewStubMyInterface(options.StubOptions{WhenExhausted: options.ZeroValue})
+	stub.GetValueReturns = StubMyInterfaceGetValueReturns{String0: "default"}
+	stub.GetValueReturnsOnCall(1, StubMyInterfaceGetValueReturns{String0: "second"})
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		got = append(got, stub.GetValue())
+	}
+	want := []string{"default", "second", "default"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d returned %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReturnsOnCallFallsBackToReturns(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.CalculateReturns = StubMyInterfaceCalculateReturns{Int0: 42}
+	stub.CalculateReturnsOnCall(0, StubMyInterfaceCalculateReturns{Error1: errTimeout})
+
+	if _, err := stub.Calculate(1, 2); err != errTimeout {
+		t.Errorf("first call returned error %v, want %v", err, errTimeout)
+	}
+	for i := 1; i < 3; i++ {
+		if got, err := stub.Calculate(1, 2); got != 42 || err != nil {
+			t.Errorf("call %d returned %d, %v, want 42, nil", i, got, err)
+		}
+	}
+}
+
+func TestReturnsSequenceReplacesValues(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.GetValueReturnsOnCall(3, StubMyInterfaceGetValueReturns{String0: "fourth"})
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{String0: "first"})
+
+	for i := 0; i < 4; i++ {
+		if got := stub.GetValue(); got != "first" {
+			t.Errorf("call %d returned %q, want %q", i, got, "first")
+		}
+	}
+}
+
+func TestReturnsSequenceStartsAtNextCall(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.GetValueReturns = StubMyInterfaceGetValueReturns{String0: "default"}
+	stub.GetValue()
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{String0: "second"})
+
+	if got := stub.GetValue(); got != "second" {
+		t.Errorf("GetValue returned %q, want %q", got, "second")
+	}
+}
+
+func TestFuncTakesPrecedenceOverSequence(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{})
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{String0: "sequenced"})
+	stub.GetValueFunc = func() string { return "func" }
+
+	if got := stub.GetValue(); got != "func" {
+		t.Errorf("GetValue returned %q, want %q", got, "func")
+	}
+}
+
+func TestExhaustedSequenceFails(t *testing.T) {
+	stub := NewStubMyInterface(options.StubOptions{WhenExhausted: options.Fail})
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{String0: "only"})
+	stub.GetValue()
+
+	defer func() {
+		want := "StubMyInterface.GetValue called more times than it has sequenced return values"
+		if r := recover(); r != want {
+			t.Errorf("second call panicked with %v, want %q", r, want)
+		}
+	}()
+	stub.GetValue()
+}
@@ -0,0 +1,121 @@
+package stubs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/phildrip/toe/matcher"
+	"github.com/phildrip/toe/options"
+)
+
+// recordingTB records fatal failures instead of ending the test.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func TestStrictFailsUnconfiguredCall(t *testing.T) {
+	tb := &recordingTB{}
+	stub := NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+
+	stub.Calculate(1, 2)
+	stub.GetValue()
+	stub.SetValue("ignored")
+
+	want := []string{
+		"unexpected call to StubMyInterface.Calculate(1, 2)",
+		"unexpected call to StubMyInterface.GetValue()",
+		`unexpected call to StubMyInterface.SetValue("ignored")`,
+	}
+	if fmt.Sprint(tb.failures) != fmt.Sprint(want) {
+		t.Errorf("failures = %q, want %q", tb.failures, want)
+	}
+}
+
+func TestStrictAllowsConfiguredCalls(t *testing.T) {
+	tb := &recordingTB{}
+	stub := NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.CalculateReturns = StubMyInterfaceCalculateReturns{Int0: 1}
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{})
+	stub.Calculate(1, 2)
+	stub.GetValue()
+
+	stub = NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.CalculateFunc = func(x, y int) (int, error) { return 0, nil }
+	stub.WhenGetValue().Return(StubMyInterfaceGetValueReturns{})
+	stub.Calculate(1, 2)
+	stub.GetValue()
+
+	// Zero values are configured when set with the setter
+	stub = NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.SetCalculateReturns(StubMyInterfaceCalculateReturns{})
+	stub.Calculate(1, 2)
+
+	// Methods without results are configured by a function or an expectation
+	stub.SetValueFunc = func(string) {}
+	stub.SetValue("func")
+	stub = NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.ExpectSetValue().Times(1)
+	stub.SetValue("expected")
+
+	if len(tb.failures) > 0 {
+		t.Errorf("configured calls failed: %q", tb.failures)
+	}
+}
+
+func TestStrictFailsCallExpectedNever(t *testing.T) {
+	tb := &recordingTB{}
+	stub := NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.ExpectSetValue().Never()
+	stub.SetValue("v")
+
+	want := `unexpected call to StubMyInterface.SetValue("v")`
+	if len(tb.failures) != 1 || tb.failures[0] != want {
+		t.Errorf("failures = %q, want [%q]", tb.failures, want)
+	}
+}
+
+func TestStrictResetForgetsSetReturns(t *testing.T) {
+	tb := &recordingTB{}
+	stub := NewStubMyInterface(options.StubOptions{Strict: true, T: tb})
+	stub.SetCalculateReturns(StubMyInterfaceCalculateReturns{})
+	stub.ResetCalculate()
+	stub.Calculate(1, 2)
+
+	want := "unexpected call to StubMyInterface.Calculate(1, 2)"
+	if len(tb.failures) != 1 || tb.failures[0] != want {
+		t.Errorf("failures = %q, want [%q]", tb.failures, want)
+	}
+}
+
+func TestStrictWithoutTPanics(t *testing.T) {
+	stub := NewStubLogger(options.StubOptions{Strict: true})
+	stub.WhenJoin(matcher.Eq("never")).Return(StubLoggerJoinReturns{String0: "matched"})
+
+	defer func() {
+		want := `unexpected call to StubLogger.Join([]string{"a", "b"})`
+		if r := recover(); r != want {
+			t.Errorf("Join panicked with %v, want %q", r, want)
+		}
+	}()
+	stub.Join("a", "b")
+}
+
+func TestExhaustedSequenceFailsTest(t *testing.T) {
+	tb := &recordingTB{}
+	stub := NewStubMyInterface(options.StubOptions{WhenExhausted: options.Fail, T: tb})
+	stub.GetValueReturnsSequence(StubMyInterfaceGetValueReturns{String0: "only"})
+	stub.GetValue()
+	stub.GetValue()
+
+	want := "StubMyInterface.GetValue called more times than it has sequenced return values"
+	if len(tb.failures) != 1 || tb.failures[0] != want {
+		t.Errorf("failures = %q, want [%q]", tb.failures, want)
+	}
+}
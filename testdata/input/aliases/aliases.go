@@ -0,0 +1,16 @@
+package aliases
+
+import (
+	"strings"
+
+	appsv1 "github.com/phildrip/toe/testdata/input/aliases/apps/v1"
+	corev1 "github.com/phildrip/toe/testdata/input/aliases/core/v1"
+	xoptions "github.com/phildrip/toe/testdata/input/aliases/options"
+	xsync "github.com/phildrip/toe/testdata/input/aliases/sync"
+)
+
+type Cluster interface {
+	Deploy(pod corev1.Pod, deployment appsv1.Deployment) error
+	Group(cfg xoptions.Config) *xsync.Group
+	Split(strings string, b *strings.Builder) []string
+}
@@ -0,0 +1,3 @@
+package options
+
+type Config struct{}
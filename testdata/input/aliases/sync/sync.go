@@ -0,0 +1,3 @@
+package sync
+
+type Group struct{}
@@ -0,0 +1,39 @@
+package collisions
+
+import "context"
+
+// Clashing has methods named like the fields and methods generated for its other
+// methods.
+type Clashing interface {
+	Do(ctx context.Context) error
+	DoFunc()
+	Get(key string) string
+	GetCalls() int
+	GetReturns() string
+	Reset()
+	Calls()
+}
+
+// Shadowing has parameters and results named like the identifiers a stub uses
+// internally.
+type Shadowing interface {
+	Save(s string, stub int, opts []string) (mu int, isLocked bool)
+	Append(append []byte, nil error) int
+	Fold(a, A int) int
+	Get(len int, make string) (int, error)
+	Blank(__ int, ___ string) error
+	Tag(maps map[string]string, slices []string)
+	Run(fn func(), returns, matched, sequenced, unexpected string) error
+}
+
+// Generic has parameters named like its type parameters.
+type Generic[T any, opts comparable] interface {
+	Put(T T, key opts)
+}
+
+// Results has results whose field names would coincide.
+type Results interface {
+	Maps() (map[string]int, map[int]string)
+	Cased() (n int, N int)
+	Mixed(key string) (_ int, Int0 string, err error)
+}
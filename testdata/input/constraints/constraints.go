@@ -0,0 +1,23 @@
+package constraints
+
+import (
+	"cmp"
+	"fmt"
+)
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Name string
+
+func (n Name) String() string { return string(n) }
+
+type Aggregator[N Number, K cmp.Ordered, L ~[]N, S interface {
+	~string
+	fmt.Stringer
+}, C comparable, U ~int | ~float64] interface {
+	Sum(values L) N
+	Label(key K, id C) S
+	Scale(u U) N
+}
@@ -0,0 +1,24 @@
+package embedded
+
+import "io"
+
+type Getter[T any] interface {
+	Get(key string) (T, error)
+}
+
+type Putter[T any] interface {
+	Put(key string, value T) error
+}
+
+type Store[T any] interface {
+	Getter[T]
+	Putter[T]
+	io.Closer
+	Keys() []string
+}
+
+type ReadStore interface {
+	io.ReadWriteCloser
+	io.Closer
+	Getter[[]byte]
+}
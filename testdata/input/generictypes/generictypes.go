@@ -0,0 +1,29 @@
+package generictypes
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type Option[T any] struct {
+	Value T
+	Valid bool
+}
+
+type List[T any] []T
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type Config struct{}
+
+type Cache[K comparable, V any] interface {
+	Lookup(key K) Option[V]
+	All() map[K]List[V]
+	Entries() []Pair[K, Option[V]]
+	Current() *atomic.Pointer[Config]
+	Touched() Option[time.Time]
+	Name() Option[string]
+}
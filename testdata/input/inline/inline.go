@@ -0,0 +1,19 @@
+package inline
+
+import (
+	"io"
+	"time"
+)
+
+type Inline interface {
+	Wrap(c interface{ Close() error }) interface {
+		io.Reader
+		Name() string
+	}
+	Configure(cfg struct {
+		Timeout time.Duration `json:"timeout"`
+		io.Writer
+		Labels map[string]struct{ Value string }
+	}) error
+	Chain(fn func(func(int) (string, error)) func() time.Time) error
+}
@@ -0,0 +1,16 @@
+package samepkg
+
+import "context"
+
+type Request struct {
+	ID string
+}
+
+type Response struct {
+	Body []byte
+}
+
+type Service interface {
+	Do(ctx context.Context, req *Request) (Response, error)
+	Batch(reqs []Request) map[string]*Response
+}
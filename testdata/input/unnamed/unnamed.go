@@ -0,0 +1,15 @@
+package unnamed
+
+import "context"
+
+type Req struct{}
+
+type Writer interface {
+	Write([]byte) (int, error)
+}
+
+type Handler interface {
+	Handle(_ context.Context, req *Req) error
+	Add(int, int) int
+	Fetch(context.Context, string, *Req) (_ []byte, err error)
+}
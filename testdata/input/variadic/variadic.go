@@ -0,0 +1,8 @@
+package variadic
+
+type Logger interface {
+	Log(format string, args ...any)
+	Join(parts ...string) string
+	Printf(prefix string, values ...int) (int, error)
+	Apply(fn func(opts ...string) error) error
+}
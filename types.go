@@ -6,8 +6,9 @@ import (
 
 // ParamData represents a parameter or result in a method signature.
 type ParamData struct {
-	Name string
-	Type types.Type // Store the actual types.Type object
+	Name     string
+	Type     types.Type // Store the actual types.Type object
+	Variadic bool       // Final ...T parameter; Type is then the []T slice
 }
 
 // MethodData represents a method of an interface.
@@ -15,6 +16,7 @@ type MethodData struct {
 	Name    string
 	Params  []ParamData
 	Results []ResultData
+	Origin  string // Embedded interface declaring the method, e.g. "io.Reader"; "" if declared directly
 }
 
 // ResultData represents a result in a method signature.
@@ -25,9 +27,13 @@ type ResultData struct {
 
 // InterfaceData represents a parsed interface, including its methods and type parameters.
 type InterfaceData struct {
-	PackageName string
-	Name        string
-	Methods     []MethodData
-	TypeParams  []ParamData       // For generic interfaces, e.g., [T comparable]
-	Imports     map[string]string // map[importPath]packageName
+	PackageName       string // Package name of the generated stub
+	PackagePath       string // Import path of the generated stub's package
+	SourcePackageName string // Package name of the package declaring the interface
+	SourcePackagePath string // Import path of the package declaring the interface
+	Name              string
+	Naming            StubNaming // Templates naming the generated declarations
+	Methods           []MethodData
+	TypeParams        []ParamData       // For generic interfaces, e.g., [T comparable]
+	Imports           map[string]string // map[importPath]packageName
 }